@@ -9,9 +9,17 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gravito-framework/quasar-go/pkg/alerting"
+	"github.com/gravito-framework/quasar-go/pkg/commands"
 	"github.com/gravito-framework/quasar-go/pkg/config"
+	"github.com/gravito-framework/quasar-go/pkg/guard"
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/gravito-framework/quasar-go/pkg/notify"
 	"github.com/gravito-framework/quasar-go/pkg/probes"
+	"github.com/gravito-framework/quasar-go/pkg/probes/fpm"
+	"github.com/gravito-framework/quasar-go/pkg/supervisor"
 	"github.com/gravito-framework/quasar-go/pkg/types"
+	"github.com/gravito-framework/quasar-go/pkg/workers"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -29,9 +37,46 @@ type Agent struct {
 	transportRedis *redis.Client // For sending heartbeats to Zenith
 	monitorRedis   *redis.Client // For inspecting local app queues (optional)
 
+	// Monitor Redis on other databases, for detected Laravel settings
+	dbClients map[int]*redis.Client
+
+	// Redis of Sidekiq queues, when not the monitor Redis (optional)
+	sidekiqRedis *redis.Client
+
 	// Probes
-	systemProbe probes.SystemProbe
-	queueProbes []probes.QueueProbe
+	systemProbe   probes.SystemProbe
+	pressureProbe *probes.PressureProbe
+	groupProbe    *probes.ProcessGroupProbe
+	fpmProbes     []*fpm.Probe
+	queueProbes   []probes.QueueProbe
+	forecaster    *probes.QueueForecaster
+
+	// Supervisor integration (optional)
+	supervisor *supervisor.Client
+
+	// Laravel failed job providers for native retries, one per project
+	failedJobs *laravel.FailedStores
+
+	// Laravel projects on this host, configured or discovered from workers
+	projects *laravel.Projects
+
+	// Built-in manager for agent-started queue workers
+	workerManager *workers.Manager
+
+	// Memory-leak guard (optional)
+	memoryGuard *guard.MemoryGuard
+
+	// Local alerting rules (optional)
+	alerts *alerting.Engine
+
+	// Direct notifications (optional)
+	notifier *notify.Dispatcher
+
+	// Error codes reported by the previous heartbeat
+	lastErrors map[string]bool
+
+	// Events of heartbeats that failed to send, published with the next one
+	unpublished []types.Event
 
 	// Command listener (for remote control)
 	commandListener *CommandListener
@@ -71,6 +116,8 @@ func New(cfg *config.Config, opts ...Option) (*Agent, error) {
 		config:      cfg,
 		logger:      slog.Default(),
 		queueProbes: []probes.QueueProbe{},
+		forecaster:  probes.NewQueueForecaster(cfg.ForecastWindow),
+		dbClients:   make(map[int]*redis.Client),
 		stopChan:    make(chan struct{}),
 	}
 
@@ -95,15 +142,123 @@ func New(cfg *config.Config, opts ...Option) (*Agent, error) {
 		a.monitorRedis = redis.NewClient(monitorOpts)
 	}
 
+	// Parse Sidekiq Redis URL if provided
+	if cfg.SidekiqRedisURL != "" {
+		sidekiqOpts, err := redis.ParseURL(cfg.SidekiqRedisURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Sidekiq redis URL: %w", err)
+		}
+		a.sidekiqRedis = redis.NewClient(sidekiqOpts)
+	}
+
 	// Create default system probe if not provided
 	if a.systemProbe == nil {
-		probe, err := probes.NewGoSystemProbe()
+		probe, err := probes.NewGoSystemProbe(
+			probes.WithDiskFilter(cfg.DiskInclude, cfg.DiskExclude),
+			probes.WithCPUSampleInterval(cfg.CPUSampleInterval),
+			probes.WithPerCoreCPU(cfg.CPUPerCore),
+		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create system probe: %w", err)
 		}
 		a.systemProbe = probe
 	}
 
+	// PSI is best-effort: reports nothing on kernels without it
+	a.pressureProbe = probes.NewPressureProbe(cfg.PSICgroupDir)
+
+	// Generic process groups (optional)
+	if len(cfg.ProcessGroups) > 0 {
+		specs := make([]probes.ProcessGroupSpec, 0, len(cfg.ProcessGroups))
+		for _, g := range cfg.ProcessGroups {
+			specs = append(specs, probes.ProcessGroupSpec{
+				Name:    g.Name,
+				Exe:     g.Exe,
+				Cmdline: g.Cmdline,
+				User:    g.User,
+				Cwd:     g.Cwd,
+			})
+		}
+		groupProbe, err := probes.NewProcessGroupProbe(specs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid process group: %w", err)
+		}
+		a.groupProbe = groupProbe
+	}
+
+	// PHP-FPM status pages (optional)
+	for _, pool := range cfg.FPMPools {
+		fpmProbe, err := fpm.NewProbe(pool.Name, pool.Address, pool.StatusPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid FPM pool %s: %w", pool.Name, err)
+		}
+		a.fpmProbes = append(a.fpmProbes, fpmProbe)
+	}
+
+	a.workerManager = workers.NewManager(a.logger, cfg.WorkerGracePeriod)
+	probes.ConfigureCrashLoopDetection(cfg.CrashLoopExits, cfg.CrashLoopWindow)
+
+	if cfg.MemoryGuard.Enabled {
+		a.memoryGuard = guard.NewMemoryGuard(guard.MemoryPolicy{
+			MaxRSS:          cfg.MemoryGuard.MaxRSS,
+			MaxGrowthPerMin: cfg.MemoryGuard.MaxGrowthPerMin,
+			TrendWindow:     cfg.MemoryGuard.TrendWindow,
+			Cooldown:        cfg.MemoryGuard.Cooldown,
+			MaxConcurrent:   cfg.MemoryGuard.MaxConcurrent,
+		})
+	}
+
+	if len(cfg.AlertRules) > 0 {
+		a.alerts = alerting.NewEngine(cfg.AlertRules)
+	}
+
+	// Direct notifications (optional)
+	notifiers, err := newNotifiers(cfg.Notify)
+	if err != nil {
+		return nil, err
+	}
+	if len(notifiers) > 0 {
+		a.notifier, err = notify.NewDispatcher(a.logger, notify.Options{
+			Template:  cfg.Notify.Template,
+			Events:    cfg.Notify.Events,
+			Retries:   cfg.Notify.Retries,
+			Backoff:   cfg.Notify.Backoff,
+			RateLimit: cfg.Notify.RateLimit,
+			Timeout:   cfg.Notify.Timeout,
+		}, notifiers...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid notify template: %w", err)
+		}
+	}
+
+	var projects []laravel.Project
+	for _, p := range cfg.LaravelProjects {
+		projects = append(projects, laravel.Project{Name: p.Name, Root: p.Root, PHP: p.PHP})
+	}
+	a.projects = laravel.NewProjects(projects, probes.DiscoverLaravelProjects)
+	if cfg.LaravelDetect != "off" {
+		a.projects.WithDetection(a.detectLaravelSettings)
+	}
+
+	// Laravel failed jobs: failed_jobs table when configured or detected, or detected Horizon
+	if cfg.LaravelFailedDSN != "" {
+		store, err := laravel.NewDatabaseFailedStore(cfg.LaravelFailedDSN, cfg.LaravelFailedTable)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Laravel failed job store: %w", err)
+		}
+		_ = store.Close()
+	}
+	a.failedJobs = laravel.NewFailedStores(a.newFailedJobStore)
+
+	// Supervisor XML-RPC (optional)
+	if cfg.SupervisorURL != "" {
+		client, err := supervisor.NewClient(cfg.SupervisorURL)
+		if err != nil {
+			return nil, err
+		}
+		a.supervisor = client
+	}
+
 	return a, nil
 }
 
@@ -128,12 +283,21 @@ func (a *Agent) Start(ctx context.Context) error {
 			a.logger.Warn("⚠️ Failed to connect to monitor Redis, stats might be missing", "error", err)
 		}
 	}
+	if a.sidekiqRedis != nil {
+		if err := a.sidekiqRedis.Ping(ctx).Err(); err != nil {
+			a.logger.Warn("⚠️ Failed to connect to Sidekiq Redis, stats might be missing", "error", err)
+		}
+	}
 
 	a.logger.Info("Quasar Agent started",
 		"service", a.config.Service,
 		"interval", a.config.Interval,
 	)
 
+	if a.notifier != nil {
+		a.notifier.Start()
+	}
+
 	// Initial tick to set nodeID
 	if err := a.tick(ctx); err != nil {
 		a.logger.Error("Initial heartbeat failed", "error", err)
@@ -168,11 +332,22 @@ func (a *Agent) Stop(ctx context.Context) error {
 	// Wait for goroutines
 	a.wg.Wait()
 
+	// Stop workers started by SCALE_WORKERS
+	a.workerManager.StopAll()
+
+	if a.notifier != nil {
+		a.notifier.Stop()
+	}
+
 	// Stop system probe if it has a Stop method
 	if probe, ok := a.systemProbe.(*probes.GoSystemProbe); ok {
 		probe.Stop()
 	}
 
+	if err := a.failedJobs.Close(); err != nil {
+		a.logger.Error("Failed to close failed job stores", "error", err)
+	}
+
 	// Close Redis connections
 	if err := a.transportRedis.Close(); err != nil {
 		a.logger.Error("Failed to close transport Redis", "error", err)
@@ -182,6 +357,16 @@ func (a *Agent) Stop(ctx context.Context) error {
 			a.logger.Error("Failed to close monitor Redis", "error", err)
 		}
 	}
+	if a.sidekiqRedis != nil {
+		if err := a.sidekiqRedis.Close(); err != nil {
+			a.logger.Error("Failed to close Sidekiq Redis", "error", err)
+		}
+	}
+	for db, client := range a.dbClients {
+		if err := client.Close(); err != nil {
+			a.logger.Error("Failed to close monitor Redis", "db", db, "error", err)
+		}
+	}
 
 	a.logger.Info("Quasar Agent stopped")
 	return nil
@@ -212,6 +397,32 @@ func (a *Agent) GetMonitorClient() *redis.Client {
 	return a.transportRedis
 }
 
+// sidekiqNamespaces returns the namespaces of Sidekiq queues configured
+// with their own, by queue name, as used by their probes
+func (a *Agent) sidekiqNamespaces() map[string]string {
+	queues := append([]config.QueueConfig(nil), a.config.Queues...)
+	for _, project := range a.config.LaravelProjects {
+		queues = append(queues, project.Queues...)
+	}
+
+	namespaces := make(map[string]string)
+	for _, q := range queues {
+		if q.Type == "sidekiq" && q.Prefix != "" {
+			namespaces[q.Name] = q.Prefix
+		}
+	}
+	return namespaces
+}
+
+// SidekiqClient returns the Redis client for Sidekiq queues.
+// If sidekiqRedis is not configured, it returns the monitor client.
+func (a *Agent) SidekiqClient() *redis.Client {
+	if a.sidekiqRedis != nil {
+		return a.sidekiqRedis
+	}
+	return a.GetMonitorClient()
+}
+
 // EnableRemoteControl enables the command listener for Zenith commands
 func (a *Agent) EnableRemoteControl(ctx context.Context) error {
 	a.mu.RLock()
@@ -228,11 +439,32 @@ func (a *Agent) EnableRemoteControl(ctx context.Context) error {
 
 	a.commandListener = NewCommandListener(
 		subscriberRedis,
+		a.transportRedis,
 		a.config.Service,
 		nodeID,
 		a.logger,
 	)
 
+	if a.supervisor != nil {
+		a.commandListener.RegisterExecutor(commands.NewSupervisorActionExecutor(a.supervisor))
+	}
+	a.commandListener.RegisterExecutor(commands.NewRetryJobExecutor(a.failedJobs, a.projects).WithSidekiq(a.SidekiqClient(), a.config.SidekiqNamespace, a.sidekiqNamespaces()))
+	a.commandListener.RegisterExecutor(commands.NewDeleteJobExecutor(a.projects).WithSidekiq(a.SidekiqClient(), a.config.SidekiqNamespace, a.sidekiqNamespaces()))
+	a.commandListener.RegisterExecutor(commands.NewPeekJobsExecutor(a.failedJobs, a.projects))
+	a.commandListener.RegisterExecutor(commands.NewBulkActionExecutor(a.failedJobs, a.projects))
+	artisan := commands.ArtisanOptions{
+		Timeout:     a.config.ArtisanTimeout,
+		OutputLimit: int(a.config.ArtisanOutputLimit),
+	}
+	a.commandListener.RegisterExecutor(commands.NewLaravelActionExecutor(a.failedJobs, a.projects, artisan))
+	a.commandListener.RegisterExecutor(commands.NewRunArtisanExecutor(a.config.ArtisanAllow, a.projects, artisan, func(event types.Event) {
+		a.publishEvents(ctx, nodeID, []types.Event{event})
+	}))
+	a.commandListener.RegisterExecutor(commands.NewScaleWorkersExecutor(a.workerManager, a.supervisor, a.config.SupervisorConfig, a.projects))
+	a.commandListener.RegisterExecutor(commands.NewWorkerActionExecutor(a.config.WorkerGracePeriod, a.projects))
+	a.commandListener.RegisterExecutor(commands.NewPauseQueueExecutor(a.config.LaravelCachePrefix, a.config.LaravelConnection, a.config.HorizonPrefix, a.projects))
+	a.commandListener.RegisterExecutor(commands.NewResumeQueueExecutor(a.config.LaravelCachePrefix, a.config.LaravelConnection, a.config.HorizonPrefix, a.projects))
+
 	if err := a.commandListener.Start(ctx, a.GetMonitorClient()); err != nil {
 		return fmt.Errorf("failed to start command listener: %w", err)
 	}
@@ -282,6 +514,7 @@ func (a *Agent) tick(ctx context.Context) error {
 
 	// Collect queue snapshots
 	var queues []types.QueueSnapshot
+	queueProbeFailed := false
 	a.mu.RLock()
 	queueProbes := a.queueProbes
 	a.mu.RUnlock()
@@ -290,10 +523,13 @@ func (a *Agent) tick(ctx context.Context) error {
 		snapshot, err := probe.GetSnapshot()
 		if err != nil {
 			a.logger.Warn("Queue probe failed", "error", err)
+			queueProbeFailed = true
 			continue
 		}
+		a.forecaster.Observe(snapshot, time.Now())
 		queues = append(queues, *snapshot)
 	}
+	a.forecaster.Retain(queues)
 
 	// Check connection health
 	var agentErrors []string
@@ -313,6 +549,73 @@ func (a *Agent) tick(ctx context.Context) error {
 		}
 	}
 
+	if queueProbeFailed {
+		if agentStatus == "online" {
+			agentStatus = "degraded"
+		}
+		agentErrors = append(agentErrors, "queue_probe_failed")
+	}
+
+	// Check local pressure thresholds
+	pressure := a.pressureProbe.GetPressure()
+	for _, exceeded := range a.exceededPSIThresholds(pressure) {
+		if agentStatus == "online" {
+			agentStatus = "degraded"
+		}
+		agentErrors = append(agentErrors, exceeded)
+	}
+
+	// Collect extra metadata (workers, process groups, PHP-FPM pools)
+	workerStats := probes.GetLaravelWorkerStats()
+	workerStats.GroupByProject(a.projects.NameFor, a.projects.Configured())
+	events := probes.DrainWorkerEvents()
+	if a.memoryGuard != nil {
+		events = append(events, a.memoryGuard.Evaluate(workerStats.Workers, time.Now())...)
+	}
+	if len(workerStats.CrashLoops) > 0 {
+		if agentStatus == "online" {
+			agentStatus = "degraded"
+		}
+		agentErrors = append(agentErrors, "worker_crash_loop")
+	}
+
+	meta := map[string]interface{}{
+		"laravel": workerStats,
+	}
+	if a.groupProbe != nil {
+		meta["processGroups"] = a.groupProbe.GetStats()
+	}
+	if len(a.fpmProbes) > 0 {
+		var pools []*fpm.PoolStatus
+		for _, fpmProbe := range a.fpmProbes {
+			status, err := fpmProbe.GetStatus(ctx)
+			if err != nil {
+				a.logger.Warn("PHP-FPM probe failed", "pool", fpmProbe.Name(), "error", err)
+				if agentStatus == "online" {
+					agentStatus = "degraded"
+				}
+				agentErrors = append(agentErrors, "fpm_"+fpmProbe.Name()+"_offline")
+				continue
+			}
+			pools = append(pools, status)
+		}
+		meta["phpFpm"] = pools
+	}
+	if a.supervisor != nil {
+		status, err := a.supervisor.GetStatus(ctx)
+		if err != nil {
+			a.logger.Warn("Supervisor status failed", "error", err)
+			if agentStatus == "online" {
+				agentStatus = "degraded"
+			}
+			agentErrors = append(agentErrors, "supervisor_offline")
+		} else {
+			meta["supervisor"] = status
+		}
+	}
+
+	events = append(events, a.errorTransitions(agentErrors)...)
+
 	// Build payload
 	payload := types.HeartbeatPayload{
 		ID:       nodeID,
@@ -324,6 +627,11 @@ func (a *Agent) tick(ctx context.Context) error {
 		Platform: metrics.Platform,
 		CPU:      metrics.CPU,
 		Memory:   metrics.Memory,
+		Disks:    metrics.Disks,
+		DiskIO:   metrics.DiskIO,
+		Network:  metrics.Network,
+		Load:     metrics.Load,
+		Pressure: pressure,
 		Queues:   queues,
 		Runtime: types.RuntimeInfo{
 			Uptime:    metrics.Uptime,
@@ -331,12 +639,23 @@ func (a *Agent) tick(ctx context.Context) error {
 			Status:    agentStatus,
 			Errors:    agentErrors,
 		},
-		Meta: map[string]interface{}{
-			"laravel": probes.GetLaravelWorkerStats(),
-		},
+		Meta:      meta,
 		Timestamp: time.Now().UnixMilli(),
 	}
 
+	// Evaluate local alert rules against the collected payload
+	if a.alerts != nil {
+		events = append(events, a.alerts.Evaluate(&payload, time.Now())...)
+		if firing := a.alerts.Firing(); len(firing) > 0 {
+			meta["alerts"] = firing
+		}
+	}
+
+	// Deliver directly first, so notifications still go out when the transport is down
+	if a.notifier != nil {
+		a.notifier.Notify(a.config.Service, nodeID, hostname, events)
+	}
+
 	// Serialize and send to Redis
 	data, err := json.Marshal(payload)
 	if err != nil {
@@ -345,9 +664,43 @@ func (a *Agent) tick(ctx context.Context) error {
 
 	key := keyPrefix + a.config.Service + ":" + nodeID
 	if err := a.transportRedis.Set(ctx, key, data, keyTTL).Err(); err != nil {
+		// Events are already drained from their sources; keep them for the next heartbeat
+		a.unpublished = append(a.unpublished, events...)
+		if excess := len(a.unpublished) - maxUnpublishedEvents; excess > 0 {
+			a.unpublished = a.unpublished[excess:]
+		}
 		return fmt.Errorf("failed to send heartbeat: %w", err)
 	}
 
 	a.logger.Debug("Heartbeat sent", "key", key, "cpu", metrics.CPU.Process)
+
+	// Publish worker lifecycle, guard and alert events
+	events = append(a.unpublished, events...)
+	a.unpublished = nil
+	a.publishEvents(ctx, nodeID, events)
 	return nil
 }
+
+// exceededPSIThresholds returns an error code for every configured PSI threshold
+// that is currently exceeded (e.g. "psi_memory_full_avg60_high")
+func (a *Agent) exceededPSIThresholds(pressure *types.PressureInfo) []string {
+	if pressure == nil {
+		return nil
+	}
+
+	var exceeded []string
+	for _, t := range a.config.PSIThresholds {
+		metrics := pressure.System
+		prefix := "psi_"
+		if t.Scope == "cgroup" {
+			metrics = pressure.Cgroup
+			prefix = "psi_cgroup_"
+		}
+
+		value, ok := metrics.Value(t.Resource, t.Kind, t.Window)
+		if ok && value >= t.Limit {
+			exceeded = append(exceeded, prefix+t.Resource+"_"+t.Kind+"_"+t.Window+"_high")
+		}
+	}
+	return exceeded
+}
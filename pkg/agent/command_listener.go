@@ -6,15 +6,25 @@ import (
 	"fmt"
 	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/gravito-framework/quasar-go/pkg/commands"
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/gravito-framework/quasar-go/pkg/probes"
 	"github.com/gravito-framework/quasar-go/pkg/types"
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	resultKeyPrefix = "gravito:quasar:result:"
+	resultTTL       = 5 * time.Minute
+)
+
 // CommandListener subscribes to Redis Pub/Sub for incoming commands from Zenith.
+// Results are published back on the transport connection.
 type CommandListener struct {
 	subscriber *redis.Client
+	publisher  *redis.Client
 	service    string
 	nodeID     string
 	logger     *slog.Logger
@@ -28,12 +38,14 @@ type CommandListener struct {
 // NewCommandListener creates a new command listener
 func NewCommandListener(
 	subscriber *redis.Client,
+	publisher *redis.Client,
 	service string,
 	nodeID string,
 	logger *slog.Logger,
 ) *CommandListener {
 	cl := &CommandListener{
 		subscriber: subscriber,
+		publisher:  publisher,
 		service:    service,
 		nodeID:     nodeID,
 		logger:     logger,
@@ -42,9 +54,12 @@ func NewCommandListener(
 	}
 
 	// Register default executors
-	cl.RegisterExecutor(commands.NewRetryJobExecutor())
-	cl.RegisterExecutor(commands.NewDeleteJobExecutor())
-	cl.RegisterExecutor(commands.NewLaravelActionExecutor())
+	projects := laravel.NewProjects(nil, probes.DiscoverLaravelProjects)
+	cl.RegisterExecutor(commands.NewRetryJobExecutor(nil, projects))
+	cl.RegisterExecutor(commands.NewDeleteJobExecutor(projects))
+	cl.RegisterExecutor(commands.NewLaravelActionExecutor(nil, projects, commands.DefaultArtisanOptions()))
+	cl.RegisterExecutor(commands.NewPeekJobsExecutor(nil, projects))
+	cl.RegisterExecutor(commands.NewBulkActionExecutor(nil, projects))
 
 	return cl
 }
@@ -59,6 +74,11 @@ func (cl *CommandListener) channel() string {
 	return fmt.Sprintf("gravito:quasar:cmd:%s:%s", cl.service, cl.nodeID)
 }
 
+// resultChannel returns the channel results are published on for this service
+func (cl *CommandListener) resultChannel() string {
+	return fmt.Sprintf("gravito:quasar:results:%s", cl.service)
+}
+
 // Start begins listening for commands
 func (cl *CommandListener) Start(ctx context.Context, monitorRedis *redis.Client) error {
 	cl.mu.Lock()
@@ -162,12 +182,42 @@ func (cl *CommandListener) processMessage(ctx context.Context, payload string, m
 		return
 	}
 
-	// Execute
-	result := executor.Execute(ctx, &cmd, monitorRedis)
+	// Execute, streaming incremental output to gravito:quasar:output:{commandId}
+	output := cl.newCommandOutput(ctx, &cmd)
+	result := executor.Execute(commands.WithOutput(ctx, output), &cmd, monitorRedis)
+	output.Close(result)
 
 	if result.Status == types.StatusSuccess {
 		cl.logger.Info("✅ Command executed", "type", cmd.Type, "message", result.Message)
 	} else {
 		cl.logger.Error("❌ Command failed", "type", cmd.Type, "message", result.Message)
 	}
+
+	cl.publishResult(ctx, &cmd, result)
+}
+
+// publishResult delivers a result back to the requester: it is stored under
+// gravito:quasar:result:{commandId}:{nodeId} for polling and published on the service's
+// results channel for live subscribers
+func (cl *CommandListener) publishResult(ctx context.Context, cmd *types.QuasarCommand, result types.CommandResult) {
+	if cl.publisher == nil || cmd.ID == "" {
+		return
+	}
+
+	data, err := json.Marshal(struct {
+		types.CommandResult
+		Type   types.CommandType `json:"type"`
+		NodeID string            `json:"nodeId"`
+	}{result, cmd.Type, cl.nodeID})
+	if err != nil {
+		cl.logger.Error("Failed to marshal command result", "id", cmd.ID, "error", err)
+		return
+	}
+
+	pipe := cl.publisher.Pipeline()
+	pipe.Set(ctx, resultKeyPrefix+cmd.ID+":"+cl.nodeID, data, resultTTL)
+	pipe.Publish(ctx, cl.resultChannel(), data)
+	if _, err := pipe.Exec(ctx); err != nil {
+		cl.logger.Warn("Failed to publish command result", "id", cmd.ID, "error", err)
+	}
 }
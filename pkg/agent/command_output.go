@@ -0,0 +1,109 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/commands"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	outputKeyPrefix = "gravito:quasar:output:"
+	outputMaxLen    = 10000 // Approximate entries kept per command stream
+
+	// Progress entries are throttled; the final one (done == total) always goes out
+	progressInterval = 250 * time.Millisecond
+)
+
+// Ensure commandOutput implements commands.OutputSink
+var _ commands.OutputSink = (*commandOutput)(nil)
+
+// commandOutput writes a command's incremental output to the Redis stream
+// gravito:quasar:output:{commandId}, ending with a "result" entry.
+// Entries carry the node ID since "*" commands run on several nodes.
+type commandOutput struct {
+	ctx          context.Context
+	cl           *CommandListener
+	cmd          *types.QuasarCommand
+	mu           sync.Mutex
+	seq          int64
+	lastProgress time.Time
+}
+
+func (cl *CommandListener) newCommandOutput(ctx context.Context, cmd *types.QuasarCommand) *commandOutput {
+	return &commandOutput{ctx: ctx, cl: cl, cmd: cmd}
+}
+
+// outputStream returns the stream key for a command
+func outputStream(commandID string) string {
+	return outputKeyPrefix + commandID
+}
+
+// Output emits a chunk of text output
+func (o *commandOutput) Output(text string) {
+	o.add(types.CommandOutput{Kind: types.OutputText, Text: text})
+}
+
+// Progress reports items done so far
+func (o *commandOutput) Progress(done, total int64) {
+	o.mu.Lock()
+	now := time.Now()
+	if now.Sub(o.lastProgress) < progressInterval && (total == 0 || done < total) {
+		o.mu.Unlock()
+		return
+	}
+	o.lastProgress = now
+	o.mu.Unlock()
+
+	o.add(types.CommandOutput{Kind: types.OutputProgress, Done: done, Total: total})
+}
+
+// Close appends the terminal result entry
+func (o *commandOutput) Close(result types.CommandResult) {
+	o.add(types.CommandOutput{Kind: types.OutputResult, Result: &result})
+}
+
+func (o *commandOutput) add(entry types.CommandOutput) {
+	if o.cl.publisher == nil || o.cmd.ID == "" {
+		return
+	}
+
+	// Sequence numbers follow stream order
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.seq++
+	entry.CommandID = o.cmd.ID
+	entry.NodeID = o.cl.nodeID
+	entry.Seq = o.seq
+	entry.Timestamp = time.Now().UnixMilli()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		o.cl.logger.Error("Failed to marshal command output", "id", o.cmd.ID, "error", err)
+		return
+	}
+
+	key := outputStream(o.cmd.ID)
+	pipe := o.cl.publisher.Pipeline()
+	pipe.XAdd(o.ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: outputMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"node":   o.cl.nodeID,
+			"seq":    strconv.FormatInt(entry.Seq, 10),
+			"kind":   entry.Kind,
+			"output": string(data),
+		},
+	})
+	pipe.Expire(o.ctx, key, resultTTL)
+	if _, err := pipe.Exec(o.ctx); err != nil {
+		o.cl.logger.Warn("Failed to publish command output", "id", o.cmd.ID, "error", err)
+	}
+}
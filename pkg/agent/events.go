@@ -0,0 +1,107 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gravito-framework/quasar-go/pkg/config"
+	"github.com/gravito-framework/quasar-go/pkg/notify"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+	"github.com/redis/go-redis/v9"
+)
+
+const eventStreamPrefix = "gravito:quasar:events:"
+
+// maxUnpublishedEvents bounds events kept while the transport Redis is down
+const maxUnpublishedEvents = 1000
+
+// eventStream returns the Redis stream Zenith reads agent events from
+func (a *Agent) eventStream() string {
+	return eventStreamPrefix + a.config.Service
+}
+
+// publishEvents logs events and appends them to the service's event stream
+func (a *Agent) publishEvents(ctx context.Context, nodeID string, events []types.Event) {
+	for _, event := range events {
+		switch event.Severity {
+		case types.SeverityCritical:
+			a.logger.Error("🚨 "+event.Message, "type", event.Type, "data", event.Data)
+		case types.SeverityWarning:
+			a.logger.Warn("⚠️ "+event.Message, "type", event.Type, "data", event.Data)
+		default:
+			a.logger.Info(event.Message, "type", event.Type)
+		}
+
+		data, err := json.Marshal(event)
+		if err != nil {
+			a.logger.Error("Failed to marshal event", "type", event.Type, "error", err)
+			continue
+		}
+
+		err = a.transportRedis.XAdd(ctx, &redis.XAddArgs{
+			Stream: a.eventStream(),
+			MaxLen: a.config.EventStreamMaxLen,
+			Approx: true,
+			Values: map[string]interface{}{
+				"node":     nodeID,
+				"type":     event.Type,
+				"severity": string(event.Severity),
+				"event":    string(data),
+			},
+		}).Err()
+		if err != nil {
+			a.logger.Warn("Failed to publish event", "type", event.Type, "error", err)
+		}
+	}
+}
+
+// errorTransitions turns changes in the heartbeat error codes into events:
+// agent.error when a code first appears and agent.recovered when it clears
+func (a *Agent) errorTransitions(agentErrors []string) []types.Event {
+	var events []types.Event
+
+	current := make(map[string]bool, len(agentErrors))
+	for _, code := range agentErrors {
+		current[code] = true
+		if a.lastErrors[code] {
+			continue
+		}
+		severity := types.SeverityWarning
+		if code == "transport_redis_offline" {
+			severity = types.SeverityCritical
+		}
+		events = append(events, types.NewEvent("agent.error", severity,
+			"Agent error: "+code, map[string]interface{}{"code": code}))
+	}
+
+	for code := range a.lastErrors {
+		if !current[code] {
+			events = append(events, types.NewEvent("agent.recovered", types.SeverityInfo,
+				"Agent recovered: "+code, map[string]interface{}{"code": code}))
+		}
+	}
+
+	a.lastErrors = current
+	return events
+}
+
+// newNotifiers builds the configured direct notification destinations
+func newNotifiers(cfg config.NotifyConfig) ([]notify.Notifier, error) {
+	var notifiers []notify.Notifier
+
+	if cfg.WebhookURL != "" {
+		notifiers = append(notifiers, notify.NewWebhookNotifier(cfg.WebhookURL))
+	}
+	if cfg.SlackURL != "" {
+		notifiers = append(notifiers, notify.NewSlackNotifier(cfg.SlackURL))
+	}
+	if cfg.Command != "" {
+		n, err := notify.NewCommandNotifier(cfg.Command)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, n)
+	}
+
+	return notifiers, nil
+}
@@ -0,0 +1,135 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/redis/go-redis/v9"
+)
+
+// settingsTimeout bounds booting a project to resolve its configuration
+const settingsTimeout = 30 * time.Second
+
+// detectLaravelSettings reads a project's queue settings as selected by
+// QUASAR_LARAVEL_DETECT. Only configured projects are booted with PHP;
+// discovered ones could be any directory a worker-like process runs in,
+// so their .env is read instead. It returns nil if they can't be read.
+func (a *Agent) detectLaravelSettings(project laravel.Project) *laravel.Settings {
+	var settings *laravel.Settings
+	var err error
+
+	if a.config.LaravelDetect == "config" && !project.Discovered {
+		ctx, cancel := context.WithTimeout(context.Background(), settingsTimeout)
+		settings, err = laravel.ResolveSettings(ctx, project.Root, project.PHP)
+		cancel()
+		if err != nil {
+			a.logger.Warn("Failed to resolve Laravel config, reading .env instead", "project", project.Name, "error", err)
+		}
+	}
+	if settings == nil {
+		settings, err = laravel.EnvSettings(project.Root)
+		if err != nil {
+			a.logger.Warn("Failed to read Laravel .env", "project", project.Name, "error", err)
+			return nil
+		}
+	}
+	a.applyLaravelOverrides(settings)
+
+	a.logger.Info("Detected Laravel settings",
+		"project", project.Name,
+		"source", settings.Source,
+		"connection", settings.QueueConnection,
+		"driver", settings.QueueDriver,
+		"queues", strings.Join(settings.Queues, ","),
+		"redisDb", settings.Redis.DB,
+		"redisPrefix", settings.Redis.Prefix,
+		"cachePrefix", settings.PauseCachePrefix(),
+		"failedDriver", settings.FailedDriver,
+	)
+	return settings
+}
+
+// applyLaravelOverrides replaces detected values with the ones set
+// explicitly, which always win. The Horizon prefix only applies to
+// projects detected to use Horizon.
+func (a *Agent) applyLaravelOverrides(settings *laravel.Settings) {
+	set := a.config.LaravelOverrides
+	if set.Connection {
+		settings.QueueConnection = a.config.LaravelConnection
+	}
+	if set.HorizonPrefix && settings.HorizonPrefix != "" {
+		settings.HorizonPrefix = a.config.HorizonPrefix
+	}
+	if set.CachePrefix {
+		// The configured prefix already includes any Redis prefix
+		settings.CacheRedis.Prefix, settings.CachePrefix = "", a.config.LaravelCachePrefix
+	}
+}
+
+// LaravelProjects returns the Laravel projects on this host
+func (a *Agent) LaravelProjects() *laravel.Projects {
+	return a.projects
+}
+
+// LaravelSettings returns the detected settings of the only Laravel project
+// on this host, or nil with none, several or detection off
+func (a *Agent) LaravelSettings() *laravel.Settings {
+	project, err := a.projects.Resolve("")
+	if err != nil {
+		return nil
+	}
+	return project.Settings
+}
+
+// MonitorClientFor returns a monitor client on the given database. Clients
+// for databases other than the monitor URL's are opened once and closed
+// on Stop.
+func (a *Agent) MonitorClientFor(db int) *redis.Client {
+	base := a.GetMonitorClient()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if client, ok := a.dbClients[db]; ok {
+		return client
+	}
+	client := laravel.RedisClient(base, db)
+	if client != base {
+		a.dbClients[db] = client
+	}
+	return client
+}
+
+// newFailedJobStore opens a project's failed job provider: its detected
+// failed_jobs table or Horizon store. QUASAR_LARAVEL_FAILED_DSN overrides
+// detection on a host with a single project; with several it can't tell
+// whose table it is and is ignored. It returns nil without a store, so
+// retries fall back to artisan.
+func (a *Agent) newFailedJobStore(project laravel.Project) (laravel.FailedJobStore, error) {
+	settings := project.Settings
+	var dsn, table string
+	if settings != nil && strings.HasPrefix(settings.FailedDriver, "database") {
+		dsn, table = settings.FailedDSN, settings.FailedTable
+	}
+	if a.config.LaravelFailedDSN != "" {
+		if only, err := a.projects.Resolve(""); err == nil && laravel.SameRoot(only.Root, project.Root) {
+			dsn, table = a.config.LaravelFailedDSN, a.config.LaravelFailedTable
+		} else {
+			a.logger.Warn("Ignoring QUASAR_LARAVEL_FAILED_DSN on a host with several Laravel projects", "project", project.Name)
+		}
+	}
+	if dsn != "" {
+		store, err := laravel.NewDatabaseFailedStore(dsn, table)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Laravel failed job store: %w", err)
+		}
+		return store, nil
+	}
+
+	if settings != nil && settings.HorizonPrefix != "" && settings.QueueDriver == "redis" {
+		return laravel.NewRedisFailedStore(a.MonitorClientFor(settings.Redis.DB), settings.HorizonPrefix), nil
+	}
+	return nil, nil
+}
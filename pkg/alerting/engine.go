@@ -0,0 +1,200 @@
+package alerting
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/config"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+)
+
+// Alert event types
+const (
+	EventFiring   = "alert.firing"
+	EventResolved = "alert.resolved"
+)
+
+// Alert is a rule instance that is currently firing
+type Alert struct {
+	Rule      string    `json:"rule"`
+	Metric    string    `json:"metric"`
+	Instance  string    `json:"instance,omitempty"`
+	Severity  string    `json:"severity"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	Since     time.Time `json:"since"`
+}
+
+// state tracks one (rule, instance) pair between evaluations
+type state struct {
+	pendingSince time.Time
+	firingSince  time.Time
+	firing       bool
+	value        float64
+}
+
+// Engine evaluates alert rules against heartbeat payloads.
+// A rule fires once its condition has held for the rule's For duration and
+// resolves as soon as it no longer holds. Each transition emits a single event.
+type Engine struct {
+	rules  []config.AlertRule
+	states map[string]map[string]*state // rule name -> instance -> state
+}
+
+// NewEngine creates an alerting engine for the given rules
+func NewEngine(rules []config.AlertRule) *Engine {
+	return &Engine{
+		rules:  rules,
+		states: make(map[string]map[string]*state),
+	}
+}
+
+// Evaluate checks every rule against the payload and returns the
+// firing/resolved transitions that happened since the previous call
+func (e *Engine) Evaluate(p *types.HeartbeatPayload, now time.Time) []types.Event {
+	var events []types.Event
+
+	for _, rule := range e.rules {
+		states := e.states[rule.Name]
+		if states == nil {
+			states = make(map[string]*state)
+			e.states[rule.Name] = states
+		}
+
+		values := Resolve(p, rule.Metric)
+
+		for _, instance := range sortedKeys(values) {
+			value := values[instance]
+			st := states[instance]
+
+			if !compare(value, rule.Op, rule.Threshold) {
+				if st != nil {
+					if st.firing {
+						events = append(events, e.event(rule, instance, value, EventResolved, types.SeverityInfo, now))
+					}
+					delete(states, instance)
+				}
+				continue
+			}
+
+			if st == nil {
+				st = &state{pendingSince: now}
+				states[instance] = st
+			}
+			st.value = value
+
+			if !st.firing && now.Sub(st.pendingSince) >= rule.For {
+				st.firing = true
+				st.firingSince = now
+				events = append(events, e.event(rule, instance, value, EventFiring, types.EventSeverity(rule.Severity), now))
+			}
+		}
+
+		// Instances that disappeared (queue removed, disk unmounted) resolve too
+		for _, instance := range sortedStateKeys(states) {
+			if _, ok := values[instance]; ok {
+				continue
+			}
+			st := states[instance]
+			if st.firing {
+				events = append(events, e.event(rule, instance, st.value, EventResolved, types.SeverityInfo, now))
+			}
+			delete(states, instance)
+		}
+	}
+
+	return events
+}
+
+// Firing returns the alerts that are currently firing
+func (e *Engine) Firing() []Alert {
+	var alerts []Alert
+
+	for _, rule := range e.rules {
+		states := e.states[rule.Name]
+		for _, instance := range sortedStateKeys(states) {
+			st := states[instance]
+			if !st.firing {
+				continue
+			}
+			alerts = append(alerts, Alert{
+				Rule:      rule.Name,
+				Metric:    rule.Metric,
+				Instance:  instance,
+				Severity:  rule.Severity,
+				Value:     st.value,
+				Threshold: rule.Threshold,
+				Since:     st.firingSince,
+			})
+		}
+	}
+
+	return alerts
+}
+
+func (e *Engine) event(rule config.AlertRule, instance string, value float64, eventType string, severity types.EventSeverity, now time.Time) types.Event {
+	subject := rule.Metric
+	if instance != "" {
+		subject = fmt.Sprintf("%s [%s]", rule.Metric, instance)
+	}
+
+	var message string
+	if eventType == EventFiring {
+		message = fmt.Sprintf("Alert %s firing: %s = %g (%s %g)", rule.Name, subject, value, rule.Op, rule.Threshold)
+	} else {
+		message = fmt.Sprintf("Alert %s resolved: %s = %g", rule.Name, subject, value)
+	}
+
+	data := map[string]interface{}{
+		"rule":      rule.Name,
+		"metric":    rule.Metric,
+		"value":     value,
+		"op":        rule.Op,
+		"threshold": rule.Threshold,
+		"for":       rule.For.String(),
+	}
+	if instance != "" {
+		data["instance"] = instance
+	}
+
+	ev := types.NewEvent(eventType, severity, message, data)
+	ev.Timestamp = now.UnixMilli()
+	return ev
+}
+
+func compare(value float64, op string, threshold float64) bool {
+	switch op {
+	case ">":
+		return value > threshold
+	case ">=":
+		return value >= threshold
+	case "<":
+		return value < threshold
+	case "<=":
+		return value <= threshold
+	case "==":
+		return value == threshold
+	case "!=":
+		return value != threshold
+	}
+	return false
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedStateKeys(m map[string]*state) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,141 @@
+package alerting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/config"
+	"github.com/gravito-framework/quasar-go/pkg/probes"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+)
+
+func queuePayload(sizes map[string]int64) *types.HeartbeatPayload {
+	p := &types.HeartbeatPayload{}
+	for name, waiting := range sizes {
+		q := types.QueueSnapshot{Name: name}
+		q.Size.Waiting = waiting
+		p.Queues = append(p.Queues, q)
+	}
+	return p
+}
+
+func TestResolve(t *testing.T) {
+	p := queuePayload(map[string]int64{"default": 10, "emails": 3})
+	p.CPU.System = 42
+	p.Memory.System.Total = 200
+	p.Memory.System.Used = 50
+	p.Disks = []types.DiskMetrics{{Mountpoint: "/var/lib", UsedPercent: 91}}
+	p.Runtime.Errors = []string{"supervisor_offline"}
+	p.Queues = append(p.Queues, types.QueueSnapshot{Name: "default", Project: "shop", Size: types.QueueSize{Waiting: 7}})
+	p.Meta = map[string]interface{}{"laravel": &probes.LaravelWorkerStats{
+		Projects: []probes.LaravelProjectStats{{Name: "shop", WorkerCount: 4}, {Name: "blog"}},
+	}}
+	for i := range p.Queues {
+		if p.Queues[i].Name == "emails" {
+			p.Queues[i].Forecast = &types.QueueForecast{GrowthRate: 12, Growing: true}
+			p.Queues[i].Size.Retry = 5
+		}
+	}
+
+	tests := []struct {
+		metric   string
+		instance string
+		expected float64
+		count    int
+	}{
+		{"cpu.system", "", 42, 1},
+		{"memory.used_percent", "", 25, 1},
+		{"queue.default.waiting", "default", 10, 1},
+		{"queue.*.waiting", "emails", 3, 3},
+		{"queue.shop/default.waiting", "shop/default", 7, 1},
+		{"project.shop.workers", "shop", 4, 1},
+		{"project.*.workers", "blog", 0, 2},
+		{"queue.*.growing", "emails", 1, 1},
+		{"queue.emails.retry", "emails", 5, 1},
+		{"queue.emails.drain_seconds", "", 0, 0},
+		{"disk./var/lib.used_percent", "/var/lib", 91, 1},
+		{"errors.count", "", 1, 1},
+		{"error.supervisor_offline", "", 1, 1},
+		{"error.redis_offline", "", 0, 1},
+		{"queue.missing.waiting", "", 0, 0},
+		{"load.1", "", 0, 0},
+		{"unknown.metric", "", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.metric, func(t *testing.T) {
+			values := Resolve(p, tt.metric)
+			if len(values) != tt.count {
+				t.Fatalf("Expected %d values, got %v", tt.count, values)
+			}
+			if tt.count > 0 && values[tt.instance] != tt.expected {
+				t.Errorf("Expected %s=%v, got %v", tt.instance, tt.expected, values[tt.instance])
+			}
+		})
+	}
+}
+
+func TestEngineForDuration(t *testing.T) {
+	engine := NewEngine([]config.AlertRule{
+		{Name: "backlog", Metric: "queue.default.waiting", Op: ">", Threshold: 100, For: time.Minute, Severity: "critical"},
+	})
+	start := time.Unix(1700000000, 0)
+
+	steps := []struct {
+		offset   time.Duration
+		waiting  int64
+		expected string
+	}{
+		{0, 500, ""},                           // pending
+		{30 * time.Second, 500, ""},            // still pending
+		{60 * time.Second, 500, EventFiring},   // held for 1m
+		{90 * time.Second, 600, ""},            // already firing, no duplicate
+		{120 * time.Second, 50, EventResolved}, // condition cleared
+		{150 * time.Second, 50, ""},
+		{180 * time.Second, 500, ""}, // pending again, timer restarted
+	}
+
+	for i, step := range steps {
+		events := engine.Evaluate(queuePayload(map[string]int64{"default": step.waiting}), start.Add(step.offset))
+
+		if step.expected == "" {
+			if len(events) != 0 {
+				t.Errorf("step %d: Expected no events, got %v", i, events)
+			}
+			continue
+		}
+		if len(events) != 1 || events[0].Type != step.expected {
+			t.Fatalf("step %d: Expected %s, got %v", i, step.expected, events)
+		}
+		if step.expected == EventFiring && events[0].Severity != types.SeverityCritical {
+			t.Errorf("step %d: Expected critical severity, got %s", i, events[0].Severity)
+		}
+	}
+}
+
+func TestEngineWildcardInstances(t *testing.T) {
+	engine := NewEngine([]config.AlertRule{
+		{Name: "backlog", Metric: "queue.*.waiting", Op: ">=", Threshold: 10, Severity: "warning"},
+	})
+	now := time.Unix(1700000000, 0)
+
+	events := engine.Evaluate(queuePayload(map[string]int64{"default": 10, "emails": 20, "low": 1}), now)
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 firing events, got %v", events)
+	}
+	if events[0].Data["instance"] != "default" || events[1].Data["instance"] != "emails" {
+		t.Errorf("Expected default and emails to fire, got %v", events)
+	}
+	if firing := engine.Firing(); len(firing) != 2 {
+		t.Errorf("Expected 2 firing alerts, got %v", firing)
+	}
+
+	// emails disappears entirely: its alert resolves
+	events = engine.Evaluate(queuePayload(map[string]int64{"default": 10}), now.Add(time.Minute))
+	if len(events) != 1 || events[0].Type != EventResolved || events[0].Data["instance"] != "emails" {
+		t.Errorf("Expected emails to resolve, got %v", events)
+	}
+	if firing := engine.Firing(); len(firing) != 1 || firing[0].Instance != "default" {
+		t.Errorf("Expected only default firing, got %v", firing)
+	}
+}
@@ -0,0 +1,306 @@
+package alerting
+
+import (
+	"strings"
+
+	"github.com/gravito-framework/quasar-go/pkg/probes"
+	"github.com/gravito-framework/quasar-go/pkg/probes/fpm"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+)
+
+// Resolve looks up a metric path in a heartbeat payload. It returns one value
+// per instance: the instance key is "" for scalar metrics and the queue name,
+// mountpoint, pool, ... for per-instance metrics. A "*" instance selects all.
+//
+// Supported paths:
+//
+//	cpu.{system,process,user,iowait,steal}
+//	memory.{used_percent,rss}  swap.used_percent  load.{1,5,15}
+//	disk.<mountpoint>.{used_percent,inodes_used_percent}
+//	queue.<name>.{waiting,active,failed,delayed,retry,dead,growth_rate,drain_seconds,growing}
+//	  (queues of a Laravel project are named <project>/<name>)
+//	workers.{count,crash_loops}
+//	project.<name>.{workers,memory,cpu,restarts}
+//	process_group.<name>.{count,cpu,memory,restarts}
+//	fpm.<pool>.{listen_queue,active_processes,idle_processes,max_children_reached,slow_requests}
+//	psi[.cgroup].<cpu|memory|io>.<some|full>.<avg10|avg60|avg300>
+//	errors.count  error.<code>
+func Resolve(p *types.HeartbeatPayload, metric string) map[string]float64 {
+	head, rest, _ := strings.Cut(metric, ".")
+	single := func(v float64, ok bool) map[string]float64 {
+		if !ok {
+			return nil
+		}
+		return map[string]float64{"": v}
+	}
+
+	switch head {
+	case "cpu":
+		return single(resolveCPU(p, rest))
+	case "memory":
+		return single(resolveMemory(p, rest))
+	case "swap":
+		if p.Memory.Swap != nil && rest == "used_percent" {
+			return single(p.Memory.Swap.UsedPercent, true)
+		}
+	case "load":
+		if p.Load != nil {
+			switch rest {
+			case "1":
+				return single(p.Load.Load1, true)
+			case "5":
+				return single(p.Load.Load5, true)
+			case "15":
+				return single(p.Load.Load15, true)
+			}
+		}
+	case "disk":
+		return resolveInstances(rest, func(instance, field string) map[string]float64 {
+			result := make(map[string]float64)
+			for _, d := range p.Disks {
+				if instance != "*" && instance != d.Mountpoint {
+					continue
+				}
+				switch field {
+				case "used_percent":
+					result[d.Mountpoint] = d.UsedPercent
+				case "inodes_used_percent":
+					result[d.Mountpoint] = d.InodesUsedPercent
+				}
+			}
+			return result
+		})
+	case "queue":
+		return resolveInstances(rest, func(instance, field string) map[string]float64 {
+			result := make(map[string]float64)
+			for _, q := range p.Queues {
+				name := queueInstance(q)
+				if instance != "*" && instance != name {
+					continue
+				}
+				if v, ok := queueField(q, field); ok {
+					result[name] = v
+				}
+			}
+			return result
+		})
+	case "project":
+		stats, ok := p.Meta["laravel"].(*probes.LaravelWorkerStats)
+		if !ok {
+			return nil
+		}
+		return resolveInstances(rest, func(instance, field string) map[string]float64 {
+			result := make(map[string]float64)
+			for _, project := range stats.Projects {
+				if instance != "*" && instance != project.Name {
+					continue
+				}
+				switch field {
+				case "workers":
+					result[project.Name] = float64(project.WorkerCount)
+				case "memory":
+					result[project.Name] = float64(project.Memory)
+				case "cpu":
+					result[project.Name] = project.CPU
+				case "restarts":
+					result[project.Name] = float64(project.Restarts)
+				}
+			}
+			return result
+		})
+	case "workers":
+		stats, ok := p.Meta["laravel"].(*probes.LaravelWorkerStats)
+		if !ok {
+			return nil
+		}
+		switch rest {
+		case "count":
+			return single(float64(stats.WorkerCount), true)
+		case "crash_loops":
+			return single(float64(len(stats.CrashLoops)), true)
+		}
+	case "process_group":
+		groups, ok := p.Meta["processGroups"].(map[string]*probes.ProcessGroupStats)
+		if !ok {
+			return nil
+		}
+		return resolveInstances(rest, func(instance, field string) map[string]float64 {
+			result := make(map[string]float64)
+			for name, g := range groups {
+				if instance != "*" && instance != name {
+					continue
+				}
+				switch field {
+				case "count":
+					result[name] = float64(g.Count)
+				case "cpu":
+					result[name] = g.CPU
+				case "memory":
+					result[name] = float64(g.Memory)
+				case "restarts":
+					result[name] = float64(g.Restarts)
+				}
+			}
+			return result
+		})
+	case "fpm":
+		pools, ok := p.Meta["phpFpm"].([]*fpm.PoolStatus)
+		if !ok {
+			return nil
+		}
+		return resolveInstances(rest, func(instance, field string) map[string]float64 {
+			result := make(map[string]float64)
+			for _, pool := range pools {
+				if instance != "*" && instance != pool.Pool {
+					continue
+				}
+				if v, ok := fpmField(pool, field); ok {
+					result[pool.Pool] = v
+				}
+			}
+			return result
+		})
+	case "psi":
+		return single(resolvePSI(p.Pressure, rest))
+	case "errors":
+		if rest == "count" {
+			return single(float64(len(p.Runtime.Errors)), true)
+		}
+	case "error":
+		for _, code := range p.Runtime.Errors {
+			if code == rest {
+				return single(1, true)
+			}
+		}
+		return single(0, true)
+	}
+
+	return nil
+}
+
+// resolveInstances splits "<instance>.<field>" (instance may contain dots) and
+// delegates to lookup, dropping empty results
+func resolveInstances(rest string, lookup func(instance, field string) map[string]float64) map[string]float64 {
+	i := strings.LastIndex(rest, ".")
+	if i <= 0 {
+		return nil
+	}
+	result := lookup(rest[:i], rest[i+1:])
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func resolveCPU(p *types.HeartbeatPayload, field string) (float64, bool) {
+	switch field {
+	case "system":
+		return p.CPU.System, true
+	case "process":
+		return p.CPU.Process, true
+	}
+
+	if p.CPU.Breakdown == nil {
+		return 0, false
+	}
+	switch field {
+	case "user":
+		return p.CPU.Breakdown.User, true
+	case "iowait":
+		return p.CPU.Breakdown.Iowait, true
+	case "steal":
+		return p.CPU.Breakdown.Steal, true
+	}
+	return 0, false
+}
+
+func resolveMemory(p *types.HeartbeatPayload, field string) (float64, bool) {
+	switch field {
+	case "used_percent":
+		if p.Memory.System.Total == 0 {
+			return 0, false
+		}
+		return 100 * float64(p.Memory.System.Used) / float64(p.Memory.System.Total), true
+	case "rss":
+		return float64(p.Memory.Process.RSS), true
+	}
+	return 0, false
+}
+
+func resolvePSI(pressure *types.PressureInfo, path string) (float64, bool) {
+	if pressure == nil {
+		return 0, false
+	}
+
+	metrics := pressure.System
+	if rest, ok := strings.CutPrefix(path, "cgroup."); ok {
+		metrics = pressure.Cgroup
+		path = rest
+	}
+
+	parts := strings.Split(path, ".")
+	if len(parts) != 3 {
+		return 0, false
+	}
+	return metrics.Value(parts[0], parts[1], parts[2])
+}
+
+// queueInstance names a queue for alerting: <project>/<name> for project queues
+func queueInstance(q types.QueueSnapshot) string {
+	if q.Project != "" {
+		return q.Project + "/" + q.Name
+	}
+	return q.Name
+}
+
+func queueField(q types.QueueSnapshot, field string) (float64, bool) {
+	switch field {
+	case "waiting":
+		return float64(q.Size.Waiting), true
+	case "active":
+		return float64(q.Size.Active), true
+	case "failed":
+		return float64(q.Size.Failed), true
+	case "delayed":
+		return float64(q.Size.Delayed), true
+	case "retry":
+		return float64(q.Size.Retry), true
+	case "dead":
+		return float64(q.Size.Dead), true
+	}
+
+	if q.Forecast == nil {
+		return 0, false
+	}
+	switch field {
+	case "growth_rate":
+		return q.Forecast.GrowthRate, true
+	case "drain_seconds":
+		if q.Forecast.DrainSeconds == nil {
+			return 0, false
+		}
+		return *q.Forecast.DrainSeconds, true
+	case "growing":
+		if q.Forecast.Growing {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func fpmField(pool *fpm.PoolStatus, field string) (float64, bool) {
+	switch field {
+	case "listen_queue":
+		return float64(pool.ListenQueue), true
+	case "active_processes":
+		return float64(pool.ActiveProcesses), true
+	case "idle_processes":
+		return float64(pool.IdleProcesses), true
+	case "max_children_reached":
+		return float64(pool.MaxChildrenReached), true
+	case "slow_requests":
+		return float64(pool.SlowRequests), true
+	}
+	return 0, false
+}
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+)
+
+// waitDelay bounds how long we wait for output pipes after the process is killed
+const waitDelay = 5 * time.Second
+
+// ArtisanOptions bounds artisan runs
+type ArtisanOptions struct {
+	Timeout     time.Duration // Process group is killed after this long (0 = no limit)
+	OutputLimit int           // Bytes of output kept, from the end (0 = unlimited)
+}
+
+// DefaultArtisanOptions returns the limits used when none are configured
+func DefaultArtisanOptions() ArtisanOptions {
+	return ArtisanOptions{Timeout: 5 * time.Minute, OutputLimit: 64 * 1024}
+}
+
+// ArtisanResult reports a finished artisan run
+type ArtisanResult struct {
+	Command    string `json:"command"`
+	ExitCode   int    `json:"exitCode"` // -1 if killed by a signal
+	DurationMs int64  `json:"durationMs"`
+	Output     string `json:"output"`
+	Truncated  bool   `json:"truncated,omitempty"` // Output was cut to its last OutputLimit bytes
+	TimedOut   bool   `json:"timedOut,omitempty"`
+}
+
+// runArtisan executes `php artisan <args>` in the project root with its PHP binary
+func runArtisan(ctx context.Context, project laravel.Project, opts ArtisanOptions, args ...string) (*ArtisanResult, error) {
+	php := project.PHP
+	if php == "" {
+		php = "php"
+	}
+	return runProcess(ctx, project.Root, opts, php, append([]string{"artisan"}, args...)...)
+}
+
+// runProcess runs a command bound to ctx and opts.Timeout. On expiry or
+// cancellation the whole process group is killed. Output is streamed to the
+// context's OutputSink as it is produced and the last opts.OutputLimit bytes are kept.
+// A non-zero exit is reported in the result, not as an error.
+func runProcess(ctx context.Context, dir string, opts ArtisanOptions, name string, args ...string) (*ArtisanResult, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	output := &tailBuffer{limit: opts.OutputLimit}
+	stream := newChunkWriter(outputFrom(ctx))
+	writer := io.MultiWriter(output, stream)
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	cmd.SysProcAttr = newSysProcAttr()
+	cmd.Cancel = func() error { return killProcessGroup(cmd.Process) }
+	cmd.WaitDelay = waitDelay
+
+	start := time.Now()
+	err := cmd.Run()
+	stream.Close()
+	result := &ArtisanResult{
+		Command:    strings.Join(append([]string{name}, args...), " "),
+		ExitCode:   -1,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	result.Output, result.Truncated = output.String()
+	if cmd.ProcessState != nil {
+		result.ExitCode = cmd.ProcessState.ExitCode()
+	}
+
+	if err != nil && ctx.Err() != nil {
+		result.TimedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+		return result, fmt.Errorf("killed after %s: %w", time.Since(start).Round(time.Millisecond), ctx.Err())
+	}
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return result, err
+	}
+	return result, nil
+}
+
+// tailBuffer is an io.Writer that keeps the last limit bytes written
+type tailBuffer struct {
+	mu        sync.Mutex
+	limit     int
+	data      []byte
+	truncated bool
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.data = append(b.data, p...)
+	// Trim lazily so long outputs are not copied on every write
+	if b.limit > 0 && len(b.data) > 2*b.limit {
+		b.data = append(b.data[:0], b.data[len(b.data)-b.limit:]...)
+		b.truncated = true
+	}
+	return len(p), nil
+}
+
+// String returns the kept output and whether anything was dropped
+func (b *tailBuffer) String() (string, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.limit > 0 && len(b.data) > b.limit {
+		return string(b.data[len(b.data)-b.limit:]), true
+	}
+	return string(b.data), b.truncated
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+
+	tests := []struct {
+		name          string
+		script        string
+		opts          ArtisanOptions
+		wantExit      int
+		wantOutput    string
+		wantTruncated bool
+		wantTimedOut  bool
+	}{
+		{"success", "echo done", ArtisanOptions{}, 0, "done\n", false, false},
+		{"exit code and stderr", "echo oops >&2; exit 3", ArtisanOptions{}, 3, "oops\n", false, false},
+		{"output keeps the tail", "printf 'aaaaabbbbbccccc'", ArtisanOptions{OutputLimit: 5}, 0, "ccccc", true, false},
+		// The background child holds the pipe open: only a process group kill ends it
+		{"timeout kills the group", "sleep 30 & sleep 30", ArtisanOptions{Timeout: 200 * time.Millisecond}, -1, "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			result, err := runProcess(context.Background(), t.TempDir(), tt.opts, "sh", "-c", tt.script)
+			if (err != nil) != tt.wantTimedOut {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result.ExitCode != tt.wantExit {
+				t.Errorf("Expected exit code %d, got %d", tt.wantExit, result.ExitCode)
+			}
+			if result.Output != tt.wantOutput {
+				t.Errorf("Expected output %q, got %q", tt.wantOutput, result.Output)
+			}
+			if result.Truncated != tt.wantTruncated {
+				t.Errorf("Expected truncated %v, got %v", tt.wantTruncated, result.Truncated)
+			}
+			if result.TimedOut != tt.wantTimedOut {
+				t.Errorf("Expected timedOut %v, got %v", tt.wantTimedOut, result.TimedOut)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("Expected run to end promptly, took %s", elapsed)
+			}
+		})
+	}
+}
+
+func TestTailBuffer(t *testing.T) {
+	b := &tailBuffer{limit: 4}
+	for _, chunk := range []string{"ab", "cdef", "ghijkl"} {
+		b.Write([]byte(chunk))
+	}
+
+	got, truncated := b.String()
+	if got != "ijkl" || !truncated {
+		t.Errorf("Expected \"ijkl\" truncated, got %q (truncated=%v)", got, truncated)
+	}
+	if strings.Contains(string(b.data), "ab") {
+		t.Errorf("Expected old output to be dropped, buffer holds %q", b.data)
+	}
+}
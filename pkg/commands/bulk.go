@@ -0,0 +1,448 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+	"github.com/redis/go-redis/v9"
+)
+
+// bulkChunkSize bounds how many jobs are read and mutated per round trip
+const bulkChunkSize = 500
+
+// BulkResult reports what a bulk action matched and changed
+type BulkResult struct {
+	Action   string `json:"action"`
+	Source   string `json:"source"`
+	Target   string `json:"target,omitempty"`
+	DryRun   bool   `json:"dryRun"`
+	Scanned  int64  `json:"scanned"`
+	Matched  int64  `json:"matched"`
+	Affected int64  `json:"affected"` // Jobs actually removed or moved (0 on dry-run)
+}
+
+// BulkActionExecutor handles BULK_ACTION commands:
+// purge, move, retry-all and promote-delayed
+type BulkActionExecutor struct {
+	BaseExecutor
+	failed   *laravel.FailedStores // Laravel failed job providers by project (optional)
+	projects *laravel.Projects     // Key prefixes of Laravel queues (optional)
+}
+
+// NewBulkActionExecutor creates a new bulk action executor
+func NewBulkActionExecutor(failed *laravel.FailedStores, projects *laravel.Projects) *BulkActionExecutor {
+	return &BulkActionExecutor{failed: failed, projects: projects}
+}
+
+// SupportedType returns BULK_ACTION
+func (e *BulkActionExecutor) SupportedType() types.CommandType {
+	return types.CmdBulkAction
+}
+
+// Execute runs a bulk action over the matching jobs
+func (e *BulkActionExecutor) Execute(ctx context.Context, cmd *types.QuasarCommand, redisClient *redis.Client) types.CommandResult {
+	p := cmd.Payload
+	if p.Queue == "" {
+		return e.Failed(cmd.ID, "Missing queue in payload")
+	}
+	// Laravel failed jobs live in the failed job provider, not the queue
+	if p.Action == "purge" && p.State == "failed" && p.Driver != types.DriverRedis {
+		return e.purgeFailed(ctx, cmd.ID, p)
+	}
+	if redisClient == nil {
+		return e.Failed(cmd.ID, "Monitor Redis is not configured")
+	}
+
+	var keyPrefix string
+	if p.Driver != types.DriverRedis {
+		client, prefix, release, err := laravelQueue(e.projects, p.Project, redisClient)
+		if err != nil {
+			return e.Failed(cmd.ID, err.Error())
+		}
+		defer release()
+		redisClient, keyPrefix = client, prefix
+	}
+
+	keys := queueKeys(p.Driver, p.Queue, keyPrefix)
+	result := BulkResult{Action: p.Action, DryRun: p.DryRun}
+	notify := keys.notify // Wakes Laravel workers blocking on the target
+
+	switch p.Action {
+	case "purge":
+		state := p.State
+		if state == "" {
+			state = "waiting"
+		}
+		source, ok := keys.state(state)
+		if !ok {
+			return e.Failed(cmd.ID, fmt.Sprintf("Cannot purge state %q for this driver", state))
+		}
+		result.Source = source
+
+	case "move":
+		if p.Target == "" || p.Target == p.Queue {
+			return e.Failed(cmd.ID, "Missing or identical target queue in payload")
+		}
+		state := p.State
+		if state == "" {
+			state = "waiting"
+		}
+		source, ok := keys.state(state)
+		if !ok {
+			return e.Failed(cmd.ID, fmt.Sprintf("Cannot move jobs from state %q for this driver", state))
+		}
+		result.Source = source
+		target := queueKeys(p.Driver, p.Target, keyPrefix)
+		result.Target, notify = target.waiting, target.notify
+
+	case "retry-all":
+		if keys.failed == "" {
+			return e.Failed(cmd.ID, "Laravel failed jobs are stored by the failed job provider; use LARAVEL_ACTION retry-all")
+		}
+		result.Source = keys.failed
+		result.Target = keys.waiting
+
+	case "promote-delayed":
+		result.Source = keys.delayed
+		result.Target = keys.waiting
+
+	default:
+		return e.Failed(cmd.ID, fmt.Sprintf("Unknown bulk action: %s", p.Action))
+	}
+
+	var err error
+	if result.Target == "" && p.Filter == nil {
+		err = e.purgeAll(ctx, redisClient, &result)
+	} else {
+		err = e.scan(ctx, redisClient, &result, notify, newJobMatcher(p.Filter, time.Now()))
+	}
+	return e.finish(cmd.ID, result, err)
+}
+
+// finish reports a bulk action that stopped with err (nil = completed)
+func (e *BulkActionExecutor) finish(cmdID string, result BulkResult, err error) types.CommandResult {
+	if err != nil {
+		return e.FailedWithData(cmdID, fmt.Sprintf("Bulk %s failed after %d jobs: %v", result.Action, result.Affected, err), result)
+	}
+
+	verb := "affected"
+	count := result.Affected
+	if result.DryRun {
+		verb = "would be affected"
+		count = result.Matched
+	}
+	return e.SuccessWithData(cmdID, fmt.Sprintf("%s: %d jobs %s in %s", result.Action, count, verb, result.Source), result)
+}
+
+// purgeFailed forgets the matching Laravel failed jobs of a queue through the
+// project's failed job provider, like `queue:flush` limited to one queue.
+// Jobs failing or retried during the purge may be scanned twice or skipped.
+func (e *BulkActionExecutor) purgeFailed(ctx context.Context, cmdID string, p types.CommandPayload) types.CommandResult {
+	_, store, err := failedJobStore(e.failed, e.projects, p.Project)
+	if err != nil {
+		return e.Failed(cmdID, err.Error())
+	}
+	if store == nil {
+		return e.Failed(cmdID, "No Laravel failed job store configured")
+	}
+
+	result := BulkResult{Action: p.Action, Source: "failed jobs of " + p.Queue, DryRun: p.DryRun}
+	matcher := newJobMatcher(p.Filter, time.Now())
+	output := outputFrom(ctx)
+
+	var total int64 = -1
+	for offset := 0; ; {
+		jobs, n, err := store.List(ctx, p.Queue, offset, bulkChunkSize)
+		if err != nil {
+			return e.finish(cmdID, result, err)
+		}
+		if total < 0 {
+			total = n
+		}
+		if len(jobs) == 0 {
+			return e.finish(cmdID, result, nil)
+		}
+		result.Scanned += int64(len(jobs))
+
+		forgotten := 0
+		for i := range jobs {
+			if !matcher.match(jobs[i].Payload) {
+				continue
+			}
+			result.Matched++
+			if result.DryRun {
+				continue
+			}
+			if err := store.Forget(ctx, jobs[i].ID); err != nil {
+				return e.finish(cmdID, result, err)
+			}
+			result.Affected++
+			forgotten++
+		}
+
+		output.Progress(result.Scanned, max(total, result.Scanned))
+
+		// Forgotten jobs shift the remaining ones towards the first page
+		offset += len(jobs) - forgotten
+	}
+}
+
+// purgeAll deletes the whole source key, counting it in the same transaction
+func (e *BulkActionExecutor) purgeAll(ctx context.Context, redisClient *redis.Client, result *BulkResult) error {
+	sorted, err := isSortedSet(ctx, redisClient, result.Source)
+	if err != nil {
+		return err
+	}
+
+	if result.DryRun {
+		n, err := keyLength(ctx, redisClient, result.Source, sorted)
+		result.Scanned, result.Matched = n, n
+		return err
+	}
+
+	var length *redis.IntCmd
+	_, err = redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if sorted {
+			length = pipe.ZCard(ctx, result.Source)
+		} else {
+			length = pipe.LLen(ctx, result.Source)
+		}
+		pipe.Del(ctx, result.Source)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	n := length.Val()
+	result.Scanned, result.Matched, result.Affected = n, n, n
+	return nil
+}
+
+// scan walks the source in chunks and removes (or moves to Target) matching jobs.
+// Jobs pushed or consumed by workers during the scan may be skipped.
+// notify is the target's notify list, if its workers use blocking pops.
+func (e *BulkActionExecutor) scan(ctx context.Context, redisClient *redis.Client, result *BulkResult, notify string, matcher *jobMatcher) error {
+	sorted, err := isSortedSet(ctx, redisClient, result.Source)
+	if err != nil {
+		return err
+	}
+	total, err := keyLength(ctx, redisClient, result.Source, sorted)
+	if err != nil {
+		return err
+	}
+	output := outputFrom(ctx)
+
+	var pos int64
+	for {
+		items, err := readChunk(ctx, redisClient, result.Source, sorted, pos)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		result.Scanned += int64(len(items))
+
+		var matched []string
+		for _, item := range items {
+			if matcher.match(item) {
+				matched = append(matched, item)
+			}
+		}
+		result.Matched += int64(len(matched))
+
+		var affected int64
+		if len(matched) > 0 && !result.DryRun {
+			affected, err = mutate(ctx, redisClient, result.Source, result.Target, notify, sorted, matched)
+			if err != nil {
+				return err
+			}
+			result.Affected += affected
+		}
+
+		output.Progress(result.Scanned, max(total, result.Scanned))
+
+		// Removed items shift the remaining ones towards the head
+		pos += int64(len(items)) - affected
+	}
+}
+
+func readChunk(ctx context.Context, redisClient *redis.Client, key string, sorted bool, pos int64) ([]string, error) {
+	if sorted {
+		return redisClient.ZRange(ctx, key, pos, pos+bulkChunkSize-1).Result()
+	}
+	return redisClient.LRange(ctx, key, pos, pos+bulkChunkSize-1).Result()
+}
+
+// mutate removes exact items from the source, pushing them to target if set
+// (plus one token per job on notify if set), and returns how many were
+// actually removed
+func mutate(ctx context.Context, redisClient *redis.Client, source, target, notify string, sorted bool, items []string) (int64, error) {
+	if target != "" {
+		args := make([]interface{}, 0, len(items)+1)
+		args = append(args, boolFlag(sorted))
+		for _, item := range items {
+			args = append(args, item)
+		}
+		keys := []string{source, target}
+		if notify != "" {
+			keys = append(keys, notify)
+		}
+		return moveToListScript.Run(ctx, redisClient, keys, args...).Int64()
+	}
+
+	if sorted {
+		members := make([]interface{}, len(items))
+		for i, item := range items {
+			members[i] = item
+		}
+		return redisClient.ZRem(ctx, source, members...).Result()
+	}
+
+	cmds, err := redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, item := range items {
+			pipe.LRem(ctx, source, 1, item)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var removed int64
+	for _, c := range cmds {
+		removed += c.(*redis.IntCmd).Val()
+	}
+	return removed, nil
+}
+
+func isSortedSet(ctx context.Context, redisClient *redis.Client, key string) (bool, error) {
+	keyType, err := redisClient.Type(ctx, key).Result()
+	return keyType == "zset", err
+}
+
+func keyLength(ctx context.Context, redisClient *redis.Client, key string, sorted bool) (int64, error) {
+	if sorted {
+		return redisClient.ZCard(ctx, key).Result()
+	}
+	return redisClient.LLen(ctx, key).Result()
+}
+
+func boolFlag(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// queueKeySet holds the Redis keys of one queue
+type queueKeySet struct {
+	waiting  string
+	delayed  string
+	reserved string
+	failed   string // Empty for Laravel (failed jobs live in the failed job provider)
+	notify   string // Laravel: one token per pushed job for workers using block_for
+}
+
+// queueKeys returns the keys for a queue, using the same layout as the probes.
+// keyPrefix is the Redis prefix of a Laravel queue connection.
+func queueKeys(driver types.QueueDriver, queue, keyPrefix string) queueKeySet {
+	if driver == types.DriverRedis {
+		return queueKeySet{
+			waiting:  queue,
+			delayed:  queue + ":delayed",
+			reserved: queue + ":active",
+			failed:   queue + ":failed",
+		}
+	}
+
+	prefix := keyPrefix + "queues:" + queue
+	return queueKeySet{
+		waiting:  prefix,
+		delayed:  prefix + ":delayed",
+		reserved: prefix + ":reserved",
+		notify:   prefix + ":notify",
+	}
+}
+
+// state returns the key for a job state name
+func (k queueKeySet) state(state string) (string, bool) {
+	var key string
+	switch state {
+	case "waiting":
+		key = k.waiting
+	case "delayed":
+		key = k.delayed
+	case "reserved":
+		key = k.reserved
+	case "failed":
+		key = k.failed
+	}
+	return key, key != ""
+}
+
+// jobMatcher applies a JobFilter to raw payloads
+type jobMatcher struct {
+	filter *types.JobFilter
+	now    time.Time
+}
+
+func newJobMatcher(filter *types.JobFilter, now time.Time) *jobMatcher {
+	return &jobMatcher{filter: filter, now: now}
+}
+
+func (m *jobMatcher) match(raw string) bool {
+	f := m.filter
+	if f == nil {
+		return true
+	}
+
+	if f.Contains != "" && !strings.Contains(raw, f.Contains) {
+		return false
+	}
+	if f.Class == "" && f.OlderThan <= 0 && len(f.IDs) == 0 {
+		return true
+	}
+
+	job := summarizeJob(raw)
+
+	if f.Class != "" {
+		if prefix, ok := strings.CutSuffix(f.Class, "*"); ok {
+			if !strings.HasPrefix(job.DisplayName, prefix) {
+				return false
+			}
+		} else if job.DisplayName != f.Class {
+			return false
+		}
+	}
+
+	if f.OlderThan > 0 {
+		if job.PushedAt == 0 || m.now.Sub(time.UnixMilli(job.PushedAt)) < time.Duration(f.OlderThan)*time.Second {
+			return false
+		}
+	}
+
+	if len(f.IDs) > 0 {
+		payload, _ := laravel.DecodePayload(raw)
+		found := false
+		for _, id := range f.IDs {
+			if (id != "" && id == job.ID) || (payload != nil && payload.Matches(id)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Ensure BulkActionExecutor implements Executor
+var _ Executor = (*BulkActionExecutor)(nil)
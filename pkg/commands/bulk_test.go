@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+)
+
+func TestJobMatcher(t *testing.T) {
+	now := time.Unix(1700000600, 0)
+	invoice := `{"uuid":"u-1","id":"q-1","displayName":"App\\Jobs\\SendInvoice","attempts":0,"pushedAt":"1700000000","data":{"command":"O:..."}}`
+	report := `{"uuid":"u-2","displayName":"App\\Reports\\Build","attempts":0,"data":{"command":"O:..."}}`
+
+	tests := []struct {
+		name     string
+		filter   *types.JobFilter
+		raw      string
+		expected bool
+	}{
+		{"no filter", nil, report, true},
+		{"exact class", &types.JobFilter{Class: `App\Jobs\SendInvoice`}, invoice, true},
+		{"exact class mismatch", &types.JobFilter{Class: `App\Jobs\Send`}, invoice, false},
+		{"class prefix", &types.JobFilter{Class: `App\Jobs\*`}, invoice, true},
+		{"class prefix mismatch", &types.JobFilter{Class: `App\Jobs\*`}, report, false},
+		{"older than", &types.JobFilter{OlderThan: 300}, invoice, true},
+		{"not old enough", &types.JobFilter{OlderThan: 900}, invoice, false},
+		{"age unknown", &types.JobFilter{OlderThan: 1}, report, false},
+		{"payload match", &types.JobFilter{Contains: "Reports"}, report, true},
+		{"payload mismatch", &types.JobFilter{Contains: "Reports"}, invoice, false},
+		{"uuid", &types.JobFilter{IDs: []string{"u-9", "u-1"}}, invoice, true},
+		{"queue id", &types.JobFilter{IDs: []string{"q-1"}}, invoice, true},
+		{"id substring is not a match", &types.JobFilter{IDs: []string{"u"}}, invoice, false},
+		{"all fields", &types.JobFilter{Class: `App\Jobs\*`, OlderThan: 60, Contains: "Invoice", IDs: []string{"u-1"}}, invoice, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newJobMatcher(tt.filter, now).match(tt.raw); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestQueueKeys(t *testing.T) {
+	laravel := queueKeys("", "emails", "")
+	if laravel.waiting != "queues:emails" || laravel.delayed != "queues:emails:delayed" || laravel.reserved != "queues:emails:reserved" {
+		t.Errorf("Unexpected Laravel keys %+v", laravel)
+	}
+	if _, ok := laravel.state("failed"); ok {
+		t.Error("Expected no failed key for Laravel")
+	}
+
+	prefixed := queueKeys("", "emails", "shop_database_")
+	if prefixed.waiting != "shop_database_queues:emails" || prefixed.reserved != "shop_database_queues:emails:reserved" ||
+		prefixed.notify != "shop_database_queues:emails:notify" {
+		t.Errorf("Unexpected prefixed Laravel keys %+v", prefixed)
+	}
+
+	list := queueKeys(types.DriverRedis, "emails", "")
+	if list.notify != "" {
+		t.Errorf("Expected no notify list for plain Redis queues, got %q", list.notify)
+	}
+	if key, ok := list.state("failed"); !ok || key != "emails:failed" {
+		t.Errorf("Expected emails:failed, got %q", key)
+	}
+	if key, _ := list.state("reserved"); key != "emails:active" {
+		t.Errorf("Expected emails:active, got %q", key)
+	}
+}
+
+func TestBulkPurgeLaravelFailedJobs(t *testing.T) {
+	// More than one chunk on default, alternating two job classes
+	store := &memoryFailedStore{}
+	for i := 0; i < bulkChunkSize+100; i++ {
+		class := `App\\Jobs\\SendMail`
+		if i%2 == 1 {
+			class = `App\\Reports\\Build`
+		}
+		store.jobs = append(store.jobs, laravel.FailedJob{ID: fmt.Sprint(i), Queue: "default", Payload: `{"uuid":"u-` + fmt.Sprint(i) + `","displayName":"` + class + `"}`})
+	}
+	store.jobs = append(store.jobs, laravel.FailedJob{ID: "emails", Queue: "emails", Payload: `{"uuid":"u-emails","displayName":"App\\Jobs\\SendMail"}`})
+
+	projects := laravel.NewProjects([]laravel.Project{{Name: "shop", Root: "/srv/shop"}}, nil)
+	stores := laravel.NewFailedStores(func(laravel.Project) (laravel.FailedJobStore, error) { return store, nil })
+	executor := NewBulkActionExecutor(stores, projects)
+
+	cmd := &types.QuasarCommand{ID: "cmd-1", Payload: types.CommandPayload{
+		Action: "purge", Queue: "default", State: "failed", DryRun: true,
+		Filter: &types.JobFilter{Class: `App\Jobs\SendMail`},
+	}}
+	result := executor.Execute(context.Background(), cmd, nil)
+	if result.Status != types.StatusSuccess {
+		t.Fatalf("Expected success, got %s: %s", result.Status, result.Message)
+	}
+	bulk := result.Data.(BulkResult)
+	want := int64(bulkChunkSize+100) / 2
+	if bulk.Matched != want || bulk.Affected != 0 || len(store.jobs) != bulkChunkSize+101 {
+		t.Fatalf("Expected %d matches and nothing removed on dry-run, got %+v with %d jobs left", want, bulk, len(store.jobs))
+	}
+
+	cmd.Payload.DryRun = false
+	result = executor.Execute(context.Background(), cmd, nil)
+	if result.Status != types.StatusSuccess {
+		t.Fatalf("Expected success, got %s: %s", result.Status, result.Message)
+	}
+	if bulk := result.Data.(BulkResult); bulk.Affected != want {
+		t.Errorf("Expected %d jobs forgotten, got %+v", want, bulk)
+	}
+	for _, job := range store.jobs {
+		if job.Queue == "default" && strings.Contains(job.Payload, "SendMail") {
+			t.Fatalf("Expected every SendMail job on default forgotten, found %s", job.ID)
+		}
+	}
+	if len(store.jobs) != int(want)+1 {
+		t.Errorf("Expected the other jobs kept, got %d", len(store.jobs))
+	}
+
+	executor = NewBulkActionExecutor(nil, projects)
+	if result := executor.Execute(context.Background(), cmd, nil); result.Status != types.StatusFailed {
+		t.Errorf("Expected failure without a failed job store, got %s", result.Status)
+	}
+}
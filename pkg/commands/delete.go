@@ -2,9 +2,11 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
 
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/gravito-framework/quasar-go/pkg/sidekiq"
 	"github.com/gravito-framework/quasar-go/pkg/types"
 	"github.com/redis/go-redis/v9"
 )
@@ -12,11 +14,20 @@ import (
 // DeleteJobExecutor handles DELETE_JOB commands
 type DeleteJobExecutor struct {
 	BaseExecutor
+	projects *laravel.Projects // Key prefixes of Laravel queues (optional)
+	sidekiq  sidekiqTarget
 }
 
 // NewDeleteJobExecutor creates a new delete executor
-func NewDeleteJobExecutor() *DeleteJobExecutor {
-	return &DeleteJobExecutor{}
+func NewDeleteJobExecutor(projects *laravel.Projects) *DeleteJobExecutor {
+	return &DeleteJobExecutor{projects: projects}
+}
+
+// WithSidekiq sets the Redis client (nil = monitor client) and namespace of
+// Sidekiq jobs, and the namespaces of queues that have their own
+func (e *DeleteJobExecutor) WithSidekiq(client *redis.Client, namespace string, queueNamespaces map[string]string) *DeleteJobExecutor {
+	e.sidekiq = newSidekiqTarget(client, namespace, queueNamespaces)
+	return e
 }
 
 // SupportedType returns DELETE_JOB
@@ -30,111 +41,82 @@ func (e *DeleteJobExecutor) Execute(ctx context.Context, cmd *types.QuasarComman
 	jobKey := cmd.Payload.JobKey
 	driver := cmd.Payload.Driver
 
-	if queue == "" || jobKey == "" {
+	// Sidekiq jobs are found by jid across queues
+	if jobKey == "" || (queue == "" && driver != types.DriverSidekiq) {
 		return e.Failed(cmd.ID, "Missing queue or jobKey in payload")
 	}
 
 	switch driver {
+	case types.DriverSidekiq:
+		return e.deleteSidekiqJob(ctx, cmd.ID, redisClient, queue, cmd.Payload.State, jobKey)
 	case types.DriverRedis:
 		return e.deleteRedisJob(ctx, cmd.ID, redisClient, queue, jobKey)
 	default:
 		// Default to Laravel pattern
-		return e.deleteLaravelJob(ctx, cmd.ID, redisClient, queue, jobKey)
+		client, keyPrefix, release, err := laravelQueue(e.projects, cmd.Payload.Project, redisClient)
+		if err != nil {
+			return e.Failed(cmd.ID, err.Error())
+		}
+		defer release()
+		return e.deleteLaravelJob(ctx, cmd.ID, client, queue, keyPrefix, jobKey)
 	}
 }
 
 // deleteRedisJob removes job from {queue}:failed or {queue}
 func (e *DeleteJobExecutor) deleteRedisJob(ctx context.Context, cmdID string, redisClient *redis.Client, queue, jobKey string) types.CommandResult {
-	// Try failed queue first
-	failedKey := queue + ":failed"
-	removed, err := e.removeFromList(ctx, redisClient, failedKey, jobKey)
-	if err != nil {
-		return e.Failed(cmdID, fmt.Sprintf("Failed to delete from failed queue: %v", err))
-	}
-	if removed {
-		return e.Success(cmdID, fmt.Sprintf("Job deleted from %s", failedKey))
-	}
+	keys := queueKeys(types.DriverRedis, queue, "")
 
-	// Try waiting queue
-	removed, err = e.removeFromList(ctx, redisClient, queue, jobKey)
-	if err != nil {
-		return e.Failed(cmdID, fmt.Sprintf("Failed to delete from queue: %v", err))
-	}
-	if removed {
-		return e.Success(cmdID, fmt.Sprintf("Job deleted from %s", queue))
+	// Try failed queue first, then waiting
+	for _, key := range []string{keys.failed, keys.waiting} {
+		payload, err := takeJob(ctx, redisClient, key, "", false, jobKey)
+		if err != nil {
+			return e.Failed(cmdID, fmt.Sprintf("Failed to delete from %s: %v", key, err))
+		}
+		if payload != "" {
+			return e.Success(cmdID, fmt.Sprintf("Job deleted from %s", key))
+		}
 	}
 
 	return e.Failed(cmdID, "Job not found in any queue")
 }
 
 // deleteLaravelJob removes job from Laravel queue
-func (e *DeleteJobExecutor) deleteLaravelJob(ctx context.Context, cmdID string, redisClient *redis.Client, queue, jobKey string) types.CommandResult {
-	prefix := "queues"
-	waitingKey := prefix + ":" + queue
-	delayedKey := prefix + ":" + queue + ":delayed"
-	reservedKey := prefix + ":" + queue + ":reserved"
-
-	// Try waiting queue (List)
-	removed, err := e.removeFromList(ctx, redisClient, waitingKey, jobKey)
-	if err == nil && removed {
-		return e.Success(cmdID, fmt.Sprintf("Job deleted from %s", waitingKey))
+func (e *DeleteJobExecutor) deleteLaravelJob(ctx context.Context, cmdID string, redisClient *redis.Client, queue, keyPrefix, jobKey string) types.CommandResult {
+	keys := queueKeys("", queue, keyPrefix)
+
+	// Waiting is a list, delayed and reserved are sorted sets
+	locations := []struct {
+		key    string
+		sorted bool
+	}{
+		{keys.waiting, false},
+		{keys.delayed, true},
+		{keys.reserved, true},
 	}
 
-	// Try delayed queue (ZSet)
-	removed, err = e.removeFromZSet(ctx, redisClient, delayedKey, jobKey)
-	if err == nil && removed {
-		return e.Success(cmdID, fmt.Sprintf("Job deleted from %s", delayedKey))
-	}
-
-	// Try reserved queue (ZSet)
-	removed, err = e.removeFromZSet(ctx, redisClient, reservedKey, jobKey)
-	if err == nil && removed {
-		return e.Success(cmdID, fmt.Sprintf("Job deleted from %s", reservedKey))
+	for _, loc := range locations {
+		payload, err := takeJob(ctx, redisClient, loc.key, "", loc.sorted, jobKey)
+		if err != nil {
+			return e.Failed(cmdID, fmt.Sprintf("Failed to delete from %s: %v", loc.key, err))
+		}
+		if payload != "" {
+			return e.Success(cmdID, fmt.Sprintf("Job deleted from %s", loc.key))
+		}
 	}
 
 	return e.Failed(cmdID, "Job not found in Laravel queues")
 }
 
-func (e *DeleteJobExecutor) removeFromList(ctx context.Context, redisClient *redis.Client, key, jobKey string) (bool, error) {
-	// Get all items
-	items, err := redisClient.LRange(ctx, key, 0, -1).Result()
-	if err != nil {
-		return false, err
-	}
-
-	// Find and remove
-	for _, item := range items {
-		if strings.Contains(item, jobKey) || item == jobKey {
-			count, err := redisClient.LRem(ctx, key, 1, item).Result()
-			if err != nil {
-				return false, err
-			}
-			return count > 0, nil
-		}
+// deleteSidekiqJob removes a job from Sidekiq's retry or dead set; jobKey is its jid
+func (e *DeleteJobExecutor) deleteSidekiqJob(ctx context.Context, cmdID string, redisClient *redis.Client, queue, state, jid string) types.CommandResult {
+	job, ns, set, err := e.sidekiq.run(ctx, redisClient, queue, state, jid, sidekiq.Delete)
+	if errors.Is(err, sidekiq.ErrJobNotFound) {
+		return e.Failed(cmdID, fmt.Sprintf("Job %s not found in the Sidekiq retry or dead set", jid))
 	}
-
-	return false, nil
-}
-
-func (e *DeleteJobExecutor) removeFromZSet(ctx context.Context, redisClient *redis.Client, key, jobKey string) (bool, error) {
-	// Get all members
-	members, err := redisClient.ZRange(ctx, key, 0, -1).Result()
 	if err != nil {
-		return false, err
-	}
-
-	// Find and remove
-	for _, member := range members {
-		if strings.Contains(member, jobKey) || member == jobKey {
-			count, err := redisClient.ZRem(ctx, key, member).Result()
-			if err != nil {
-				return false, err
-			}
-			return count > 0, nil
-		}
+		return e.Failed(cmdID, fmt.Sprintf("Failed to delete job %s: %v", jid, err))
 	}
-
-	return false, nil
+	return e.Success(cmdID, fmt.Sprintf("Job %s (%s) deleted from %s", jid, job.Class, ns.Key(set)))
 }
 
 // Ensure DeleteJobExecutor implements Executor
@@ -25,7 +25,17 @@ func (e *BaseExecutor) Success(commandID, message string) types.CommandResult {
 	return types.NewSuccessResult(commandID, message)
 }
 
+// SuccessWithData creates a success result carrying structured data
+func (e *BaseExecutor) SuccessWithData(commandID, message string, data interface{}) types.CommandResult {
+	return types.NewSuccessResultWithData(commandID, message, data)
+}
+
 // Failed creates a failed result
 func (e *BaseExecutor) Failed(commandID, message string) types.CommandResult {
 	return types.NewFailedResult(commandID, message)
 }
+
+// FailedWithData creates a failed result carrying structured data
+func (e *BaseExecutor) FailedWithData(commandID, message string, data interface{}) types.CommandResult {
+	return types.NewFailedResultWithData(commandID, message, data)
+}
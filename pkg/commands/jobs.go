@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+)
+
+// maxInlineJobKey is the largest raw payload returned as a jobKey when the
+// job has no ID of its own
+const maxInlineJobKey = 4096
+
+// JobSummary is a decoded, size-bounded view of a queued job
+type JobSummary struct {
+	ID          string `json:"id,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	Attempts    int    `json:"attempts"`
+	PushedAt    int64  `json:"pushedAt,omitempty"`    // Unix ms
+	AvailableAt int64  `json:"availableAt,omitempty"` // Unix ms (delayed: due time, reserved: reservation expiry)
+	Size        int    `json:"size"`                  // Raw payload size in bytes
+	JobKey      string `json:"jobKey,omitempty"`      // Value to pass as jobKey to RETRY_JOB / DELETE_JOB
+	FailedAt    int64  `json:"failedAt,omitempty"`    // Unix ms (Laravel failed jobs)
+	Exception   string `json:"exception,omitempty"`   // First line of the exception (Laravel failed jobs)
+}
+
+// genericPayload covers common fields of non-Laravel Redis job formats
+type genericPayload struct {
+	ID           json.RawMessage `json:"id"`
+	JobID        json.RawMessage `json:"jobId"`
+	Name         string          `json:"name"`
+	Job          string          `json:"job"`
+	Attempts     *int            `json:"attempts"`
+	AttemptsMade *int            `json:"attemptsMade"`
+	Timestamp    json.RawMessage `json:"timestamp"`
+}
+
+// summarizeJob decodes what it can from a raw job payload.
+// Payloads that aren't JSON still get a size and jobKey.
+func summarizeJob(raw string) JobSummary {
+	summary := JobSummary{Size: len(raw)}
+
+	if p, err := laravel.DecodePayload(raw); err == nil && p.UUID != "" {
+		summary.ID = p.UUID
+		summary.DisplayName = p.DisplayName
+		summary.Attempts = p.Attempts
+		if !p.PushedAt.IsZero() {
+			summary.PushedAt = p.PushedAt.UnixMilli()
+		}
+	} else {
+		var g genericPayload
+		if err := json.Unmarshal([]byte(raw), &g); err == nil {
+			summary.ID = firstNonEmpty(rawString(g.ID), rawString(g.JobID))
+			summary.DisplayName = firstNonEmpty(g.Name, g.Job)
+
+			switch {
+			case g.Attempts != nil:
+				summary.Attempts = *g.Attempts
+			case g.AttemptsMade != nil:
+				summary.Attempts = *g.AttemptsMade
+			}
+
+			if ts, ok := rawTimestamp(g.Timestamp); ok {
+				summary.PushedAt = ts
+			}
+		}
+	}
+
+	switch {
+	case summary.ID != "":
+		summary.JobKey = summary.ID
+	case len(raw) <= maxInlineJobKey:
+		summary.JobKey = raw
+	}
+
+	return summary
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// rawString returns a JSON string or number as a string
+func rawString(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n.String()
+	}
+	return ""
+}
+
+// rawTimestamp parses a Unix timestamp given in seconds (possibly fractional,
+// possibly as a string) or milliseconds, and returns Unix ms
+func rawTimestamp(raw json.RawMessage) (int64, bool) {
+	s := rawString(raw)
+	if s == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f <= 0 {
+		return 0, false
+	}
+	return unixMillis(f), true
+}
+
+// unixMillis converts a Unix timestamp in seconds or milliseconds to ms
+func unixMillis(ts float64) int64 {
+	// Anything past year 5138 in seconds is treated as milliseconds
+	if ts >= 1e11 {
+		return int64(ts)
+	}
+	return int64(math.Round(ts * 1000))
+}
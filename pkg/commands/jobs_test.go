@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSummarizeJob(t *testing.T) {
+	laravel := `{"uuid":"9a1c7b2e-1f7d-4c52-8f36-0f5c2b8e6a11","displayName":"App\\Jobs\\SendInvoice","job":"Illuminate\\Queue\\CallQueuedHandler@call","maxTries":3,"attempts":2,"pushedAt":"1700000000.1234","id":"Xk2","data":{"commandName":"App\\Jobs\\SendInvoice"}}`
+
+	tests := []struct {
+		name     string
+		raw      string
+		expected JobSummary
+	}{
+		{
+			name: "laravel payload",
+			raw:  laravel,
+			expected: JobSummary{
+				ID:          "9a1c7b2e-1f7d-4c52-8f36-0f5c2b8e6a11",
+				DisplayName: `App\Jobs\SendInvoice`,
+				Attempts:    2,
+				PushedAt:    1700000000123,
+				Size:        len(laravel),
+				JobKey:      "9a1c7b2e-1f7d-4c52-8f36-0f5c2b8e6a11",
+			},
+		},
+		{
+			name: "generic job with numeric id and ms timestamp",
+			raw:  `{"id":42,"name":"resize","attemptsMade":1,"timestamp":1700000000123}`,
+			expected: JobSummary{
+				ID:          "42",
+				DisplayName: "resize",
+				Attempts:    1,
+				PushedAt:    1700000000123,
+				Size:        68,
+				JobKey:      "42",
+			},
+		},
+		{
+			name:     "not json",
+			raw:      "plain-job",
+			expected: JobSummary{Size: 9, JobKey: "plain-job"},
+		},
+		{
+			name:     "large payload without id",
+			raw:      `"` + strings.Repeat("x", maxInlineJobKey) + `"`,
+			expected: JobSummary{Size: maxInlineJobKey + 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := summarizeJob(tt.raw)
+			if got != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestUnixMillis(t *testing.T) {
+	tests := []struct {
+		input    float64
+		expected int64
+	}{
+		{1700000000, 1700000000000},
+		{1700000000.5, 1700000000500},
+		{1700000000123, 1700000000123},
+	}
+
+	for _, tt := range tests {
+		if got := unixMillis(tt.input); got != tt.expected {
+			t.Errorf("unixMillis(%v): Expected %d, got %d", tt.input, tt.expected, got)
+		}
+	}
+}
@@ -3,12 +3,14 @@ package commands
 import (
 	"context"
 	"fmt"
-	"os/exec"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/gravito-framework/quasar-go/pkg/probes"
 	"github.com/gravito-framework/quasar-go/pkg/types"
 	"github.com/redis/go-redis/v9"
-	"github.com/shirou/gopsutil/v3/process"
 )
 
 // Ensure LaravelActionExecutor implements Executor
@@ -17,11 +19,14 @@ var _ Executor = (*LaravelActionExecutor)(nil)
 // LaravelActionExecutor handles LARAVEL_ACTION commands
 type LaravelActionExecutor struct {
 	BaseExecutor
+	failed   *laravel.FailedStores // Enables native retries without artisan (optional)
+	projects *laravel.Projects
+	artisan  ArtisanOptions
 }
 
 // NewLaravelActionExecutor creates a new Laravel executor
-func NewLaravelActionExecutor() *LaravelActionExecutor {
-	return &LaravelActionExecutor{}
+func NewLaravelActionExecutor(failed *laravel.FailedStores, projects *laravel.Projects, artisan ArtisanOptions) *LaravelActionExecutor {
+	return &LaravelActionExecutor{failed: failed, projects: projects, artisan: artisan}
 }
 
 // SupportedType returns LARAVEL_ACTION
@@ -36,123 +41,207 @@ func (e *LaravelActionExecutor) Execute(ctx context.Context, cmd *types.QuasarCo
 		return e.Failed(cmd.ID, "Missing action in payload")
 	}
 
-	// 1. Discover Laravel Root
-	root, err := e.discoverLaravelRoot()
+	// 1. Resolve the target project
+	project, err := e.projects.Resolve(cmd.Payload.Project)
 	if err != nil {
 		return e.Failed(cmd.ID, fmt.Sprintf("Laravel project not found: %v", err))
 	}
 
+	// Retries go through the project's failed job store directly when it has one
+	if redisClient != nil && (action == "retry-all" || action == "retry") {
+		store, err := e.failed.For(project)
+		if err != nil {
+			return e.Failed(cmd.ID, fmt.Sprintf("Failed to open the failed job store of %s: %v", project.Name, err))
+		}
+		if store != nil {
+			clients := newRedisClients(redisClient)
+			defer clients.close()
+
+			if action == "retry-all" {
+				return e.retryAllNative(ctx, cmd.ID, store, project.Settings, clients)
+			}
+			if cmd.Payload.JobID == "" {
+				return e.Failed(cmd.ID, "Missing jobId for retry action")
+			}
+			return retryFailedJob(ctx, &e.BaseExecutor, cmd.ID, store, project.Settings, clients, cmd.Payload.JobID)
+		}
+	}
+
 	// 2. Execute Action
 	switch action {
 	case "retry-all":
-		return e.runArtisan(cmd.ID, root, "queue:retry", "all")
+		return e.artisanCommand(ctx, cmd.ID, project, "queue:retry", "all")
 	case "restart":
 		// Precision Targeting: Only restart workers on this machine for this project
-		return e.restartLocalWorkers(cmd.ID, root)
+		return e.restartLocalWorkers(ctx, cmd.ID, project.Root)
 	case "retry":
 		jobID := cmd.Payload.JobID
 		if jobID == "" {
 			return e.Failed(cmd.ID, "Missing jobId for retry action")
 		}
-		return e.runArtisan(cmd.ID, root, "queue:retry", jobID)
+		return e.artisanCommand(ctx, cmd.ID, project, "queue:retry", jobID)
 	default:
 		return e.Failed(cmd.ID, fmt.Sprintf("Unknown Laravel action: %s", action))
 	}
 }
 
-// discoverLaravelRoot scans processes to find a running Laravel artisan command and its CWD
-func (e *LaravelActionExecutor) discoverLaravelRoot() (string, error) {
-	procs, err := process.Processes()
+// RetryAllResult reports a native retry-all run
+type RetryAllResult struct {
+	Retried int      `json:"retried"`
+	Failed  int      `json:"failed"`
+	Errors  []string `json:"errors,omitempty"` // First few failures
+}
+
+// retryAllNative retries every failed job, oldest first, like `queue:retry all`
+func (e *LaravelActionExecutor) retryAllNative(ctx context.Context, cmdID string, store laravel.FailedJobStore, settings *laravel.Settings, clients *redisClients) types.CommandResult {
+	ids, err := store.IDs(ctx)
 	if err != nil {
-		return "", err
+		return e.Failed(cmdID, fmt.Sprintf("Failed to list failed jobs: %v", err))
 	}
 
-	for _, p := range procs {
-		if e.isLaravelWorker(p) {
-			cwd, err := p.Cwd()
-			if err == nil && cwd != "" {
-				return cwd, nil
+	var result RetryAllResult
+	output := outputFrom(ctx)
+	for i, id := range ids {
+		output.Progress(int64(i), int64(len(ids)))
+		if _, err := laravel.Retry(ctx, store, settings, clients.get, id); err != nil {
+			result.Failed++
+			if len(result.Errors) < 10 {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", id, err))
 			}
+			continue
 		}
+		result.Retried++
 	}
+	output.Progress(int64(len(ids)), int64(len(ids)))
 
-	return "", fmt.Errorf("could not find any running artisan processes")
+	message := fmt.Sprintf("Retried %d of %d failed jobs", result.Retried, len(ids))
+	if result.Failed > 0 {
+		return e.FailedWithData(cmdID, message, result)
+	}
+	return e.SuccessWithData(cmdID, message, result)
+}
+
+// restartConfirmTimeout bounds the wait for restarted workers to exit and come back
+const restartConfirmTimeout = 30 * time.Second
+
+// WorkerRestartResult reports a local worker restart
+type WorkerRestartResult struct {
+	Signaled  []int32  `json:"signaled"`
+	Exited    []int32  `json:"exited"`
+	Respawned []int32  `json:"respawned"` // New worker PIDs in the project
+	Confirmed bool     `json:"confirmed"`
+	Errors    []string `json:"errors,omitempty"`
 }
 
 // restartLocalWorkers finds all local worker processes for the discovered root and sends SIGTERM
 // This is "Supervisor Friendly" because Supervisor will see the exit and restart them.
 // It is "Non-Interference" because it doesn't touch Redis/Cache to affect other servers.
-func (e *LaravelActionExecutor) restartLocalWorkers(cmdID, root string) types.CommandResult {
-	procs, err := process.Processes()
+// The restart is confirmed once every signaled worker has exited and as many new
+// workers are running in the project.
+func (e *LaravelActionExecutor) restartLocalWorkers(ctx context.Context, cmdID, root string) types.CommandResult {
+	workers, err := listWorkers(ctx)
 	if err != nil {
 		return e.Failed(cmdID, fmt.Sprintf("Failed to list processes: %v", err))
 	}
-
-	count := 0
-	var errors []string
-
-	for _, p := range procs {
-		// 1. Check if it's a Laravel worker
-		if !e.isLaravelWorker(p) {
+	targets := workersInRoot(workers, root)
+
+	var result WorkerRestartResult
+	var signaled []workerTarget
+	for _, t := range targets {
+		// Terminate sends SIGTERM, which tells Laravel workers to finish current job and exit.
+		// The exit is ours, not a crash.
+		probes.ExpectWorkerExit(t.pid)
+		if err := sendSignal(ctx, t.pid, syscall.SIGTERM); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("PID %d: %v", t.pid, err))
 			continue
 		}
+		signaled = append(signaled, t)
+		result.Signaled = append(result.Signaled, t.pid)
+	}
 
-		// 2. Check if it belongs to THIS project (Precision Targeting)
-		cwd, err := p.Cwd()
-		if err != nil {
-			continue
+	if len(signaled) == 0 {
+		if len(result.Errors) > 0 {
+			return e.FailedWithData(cmdID, fmt.Sprintf("Failed to signal workers in %s", root), result)
 		}
+		return e.Failed(cmdID, fmt.Sprintf("No active workers found in %s to restart", root))
+	}
 
-		if cwd == root {
-			// 3. Send Signal (Supervisor Friendly)
-			// Terminate sends SIGTERM, which tells Laravel workers to finish current job and exit.
-			if err := p.Terminate(); err != nil {
-				errors = append(errors, fmt.Sprintf("PID %d: %v", p.Pid, err))
-			} else {
-				count++
-			}
+	deadline := time.Now().Add(restartConfirmTimeout)
+	alive := waitForExit(ctx, signaled, restartConfirmTimeout)
+	for i, t := range signaled {
+		if !alive[i] {
+			result.Exited = append(result.Exited, t.pid)
 		}
 	}
+	result.Respawned = waitForRespawn(ctx, workers, root, len(result.Exited), time.Until(deadline))
+	result.Confirmed = len(result.Exited) == len(signaled) && len(result.Respawned) >= len(signaled)
 
-	if count == 0 {
-		return e.Failed(cmdID, fmt.Sprintf("No active workers found in %s to restart", root))
+	msg := fmt.Sprintf("Signaled %d local workers to restart: %d exited, %d respawned",
+		len(signaled), len(result.Exited), len(result.Respawned))
+	if len(result.Errors) > 0 {
+		msg += fmt.Sprintf(". Errors: %s", strings.Join(result.Errors, "; "))
 	}
-
-	msg := fmt.Sprintf("Signaled %d local workers to restart", count)
-	if len(errors) > 0 {
-		msg += fmt.Sprintf(". Errors: %s", strings.Join(errors, "; "))
+	if !result.Confirmed {
+		return e.FailedWithData(cmdID, msg+" (restart unconfirmed)", result)
 	}
-
-	return e.Success(cmdID, msg)
+	return e.SuccessWithData(cmdID, msg, result)
 }
 
-// isLaravelWorker checks if a process looks like 'artisan queue:work' or 'horizon'
-func (e *LaravelActionExecutor) isLaravelWorker(p *process.Process) bool {
-	cmdline, err := p.Cmdline()
-	if err != nil {
-		return false
+// waitForRespawn polls until at least want workers that were not running
+// before have started in the project root, or the timeout passes
+func waitForRespawn(ctx context.Context, before []workerTarget, root string, want int, timeout time.Duration) []int32 {
+	type processKey struct {
+		pid        int32
+		createTime int64
+	}
+	existing := make(map[processKey]bool, len(before))
+	for _, w := range before {
+		existing[processKey{w.pid, w.createTime}] = true
 	}
 
-	// Look for 'php artisan' or 'artisan'
-	if !strings.Contains(cmdline, "artisan") {
-		return false
+	deadline := time.Now().Add(timeout)
+	for {
+		var respawned []int32
+		if workers, err := listWorkers(ctx); err == nil {
+			for _, w := range workersInRoot(workers, root) {
+				if !existing[processKey{w.pid, w.createTime}] {
+					respawned = append(respawned, w.pid)
+				}
+			}
+		}
+		if len(respawned) >= want || !time.Now().Before(deadline) || ctx.Err() != nil {
+			return respawned
+		}
+		sleepCtx(ctx, workerPollInterval)
 	}
+}
 
-	// Look for worker indicators
-	return strings.Contains(cmdline, "queue:work") || strings.Contains(cmdline, "horizon")
+// workersInRoot filters workers to those running in a project root
+func workersInRoot(workers []workerTarget, root string) []workerTarget {
+	var selected []workerTarget
+	for _, w := range workers {
+		if laravel.SameRoot(w.root, root) {
+			selected = append(selected, w)
+		}
+	}
+	return selected
 }
 
-// runArtisan executes a php artisan command in the specified directory
-func (e *LaravelActionExecutor) runArtisan(cmdID, root string, args ...string) types.CommandResult {
+// artisanCommand runs a php artisan command in the project
+func (e *LaravelActionExecutor) artisanCommand(ctx context.Context, cmdID string, project laravel.Project, args ...string) types.CommandResult {
 	// Security: Only allow specific artisan commands (whitelisting is indirect via Execute switch)
-	allArgs := append([]string{"artisan"}, args...)
-	cmd := exec.Command("php", allArgs...)
-	cmd.Dir = root
+	result, err := runArtisan(ctx, project, e.artisan, args...)
+	return artisanCommandResult(&e.BaseExecutor, cmdID, result, err)
+}
 
-	output, err := cmd.CombinedOutput()
+// artisanCommandResult turns an artisan run into a command result
+func artisanCommandResult(e *BaseExecutor, cmdID string, result *ArtisanResult, err error) types.CommandResult {
 	if err != nil {
-		return e.Failed(cmdID, fmt.Sprintf("Artisan command failed: %v\nOutput: %s", err, string(output)))
+		return e.FailedWithData(cmdID, fmt.Sprintf("Artisan command failed: %v\nOutput: %s", err, result.Output), result)
+	}
+	if result.ExitCode != 0 {
+		return e.FailedWithData(cmdID, fmt.Sprintf("Artisan command exited with code %d\nOutput: %s", result.ExitCode, result.Output), result)
 	}
 
-	return e.Success(cmdID, fmt.Sprintf("Artisan success: %s", string(output)))
+	return e.SuccessWithData(cmdID, fmt.Sprintf("Artisan success: %s", result.Output), result)
 }
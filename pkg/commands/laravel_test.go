@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/gravito-framework/quasar-go/pkg/probes"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+)
+
+// workerPool stands in for Supervisor: it runs copies of one worker
+// cmdline and starts a new copy whenever one exits
+type workerPool struct {
+	mu      sync.Mutex
+	stopped bool
+	cmds    []*exec.Cmd
+	wg      sync.WaitGroup
+}
+
+func startWorkerPool(t *testing.T, root string, n int) *workerPool {
+	t.Helper()
+	pool := &workerPool{}
+	for i := 0; i < n; i++ {
+		pool.wg.Add(1)
+		go pool.run(t, root)
+	}
+	t.Cleanup(pool.stop)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		running, _ := listWorkers(context.Background())
+		if len(workersInRoot(running, root)) == n || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return pool
+}
+
+func (p *workerPool) run(t *testing.T, root string) {
+	defer p.wg.Done()
+	for {
+		// The cmdline contains "artisan queue:work", like a real worker
+		cmd := exec.Command("sh", "-c", `trap 'exit 0' TERM; while :; do sleep 0.05; done`, "artisan", "queue:work")
+		cmd.Dir = root
+		p.mu.Lock()
+		if p.stopped {
+			p.mu.Unlock()
+			return
+		}
+		if err := cmd.Start(); err != nil {
+			p.mu.Unlock()
+			t.Errorf("Failed to start worker: %v", err)
+			return
+		}
+		p.cmds = append(p.cmds, cmd)
+		p.mu.Unlock()
+		_ = cmd.Wait()
+	}
+}
+
+func (p *workerPool) stop() {
+	p.mu.Lock()
+	p.stopped = true
+	for _, cmd := range p.cmds {
+		_ = cmd.Process.Kill()
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+func TestLaravelRestartIsNotACrashLoop(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+
+	const workers = 5
+	probes.ConfigureCrashLoopDetection(workers, time.Minute)
+	defer probes.ConfigureCrashLoopDetection(5, 5*time.Minute)
+
+	root := t.TempDir()
+	startWorkerPool(t, root, workers)
+
+	// Baseline scan, then forget what other tests left behind
+	probes.GetLaravelWorkerStats()
+	probes.DrainWorkerEvents()
+
+	projects := laravel.NewProjects([]laravel.Project{{Name: "app", Root: root}}, nil)
+	executor := NewLaravelActionExecutor(nil, projects, DefaultArtisanOptions())
+	cmd := &types.QuasarCommand{ID: "cmd-1", Payload: types.CommandPayload{Action: "restart", Project: "app"}}
+	if result := executor.Execute(context.Background(), cmd, nil); result.Status != types.StatusSuccess {
+		t.Fatalf("Expected a confirmed restart, got %s: %s", result.Status, result.Message)
+	}
+
+	assertExpectedExits(t, root, workers)
+}
+
+// assertExpectedExits scans workers and checks that the exits in root were
+// reported as expected, without restarts or crash loops
+func assertExpectedExits(t *testing.T, root string, want int) {
+	t.Helper()
+	probes.GetLaravelWorkerStats()
+	exits := 0
+	for _, event := range probes.DrainWorkerEvents() {
+		if !laravel.SameRoot(event.Data["root"].(string), root) {
+			continue
+		}
+		switch event.Type {
+		case probes.EventWorkerCrashLoop:
+			t.Errorf("Expected no crash loop, got %s", event.Message)
+		case probes.EventWorkerRestarted:
+			t.Errorf("Expected a requested exit not to count as a restart, got %s", event.Message)
+		case probes.EventWorkerExited:
+			exits++
+			if event.Data["expected"] != true {
+				t.Errorf("Expected exit to be marked expected: %v", event.Data)
+			}
+		}
+	}
+	if exits != want {
+		t.Errorf("Expected %d exits, got %d", want, exits)
+	}
+}
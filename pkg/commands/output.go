@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// Output chunking: flush once this much is buffered or this long has passed
+const (
+	outputChunkSize     = 4 * 1024
+	outputFlushInterval = 250 * time.Millisecond
+)
+
+// OutputSink receives incremental output of a running command
+type OutputSink interface {
+	// Output emits a chunk of text output
+	Output(text string)
+	// Progress reports items done so far; total is 0 when unknown
+	Progress(done, total int64)
+}
+
+type outputKey struct{}
+
+// WithOutput attaches an output sink to the context commands are executed with
+func WithOutput(ctx context.Context, sink OutputSink) context.Context {
+	return context.WithValue(ctx, outputKey{}, sink)
+}
+
+// outputFrom returns the context's output sink, or one that discards everything
+func outputFrom(ctx context.Context) OutputSink {
+	if sink, ok := ctx.Value(outputKey{}).(OutputSink); ok && sink != nil {
+		return sink
+	}
+	return discardOutput{}
+}
+
+type discardOutput struct{}
+
+func (discardOutput) Output(string)         {}
+func (discardOutput) Progress(int64, int64) {}
+
+// Ensure chunkWriter implements io.WriteCloser
+var _ io.WriteCloser = (*chunkWriter)(nil)
+
+// chunkWriter batches process output into sink chunks. Buffered output is
+// also flushed on a ticker, so output followed by silence still streams.
+// Close must be called to stop the ticker.
+type chunkWriter struct {
+	mu        sync.Mutex
+	sink      OutputSink
+	buf       []byte
+	lastFlush time.Time
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newChunkWriter(sink OutputSink) *chunkWriter {
+	w := &chunkWriter{sink: sink, lastFlush: time.Now(), done: make(chan struct{})}
+	go w.flushLoop()
+	return w
+}
+
+func (w *chunkWriter) flushLoop() {
+	ticker := time.NewTicker(outputFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			w.mu.Lock()
+			if len(w.buf) > 0 && time.Since(w.lastFlush) >= outputFlushInterval {
+				w.flushLocked()
+			}
+			w.mu.Unlock()
+		}
+	}
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= outputChunkSize || time.Since(w.lastFlush) >= outputFlushInterval {
+		w.flushLocked()
+	}
+	return len(p), nil
+}
+
+// Flush emits whatever is still buffered
+func (w *chunkWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.flushLocked()
+}
+
+// Close stops the flush ticker and emits whatever is still buffered
+func (w *chunkWriter) Close() error {
+	w.closeOnce.Do(func() { close(w.done) })
+	w.Flush()
+	return nil
+}
+
+func (w *chunkWriter) flushLocked() {
+	for len(w.buf) > 0 {
+		n := min(len(w.buf), outputChunkSize)
+		// Prefer to split on a line boundary
+		if n < len(w.buf) {
+			if i := bytes.LastIndexByte(w.buf[:n], '\n'); i >= 0 {
+				n = i + 1
+			}
+		}
+		w.sink.Output(string(w.buf[:n]))
+		w.buf = w.buf[n:]
+	}
+	w.buf = nil
+	w.lastFlush = time.Now()
+}
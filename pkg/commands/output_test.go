@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingSink collects output chunks
+type recordingSink struct {
+	mu     sync.Mutex
+	chunks []string
+}
+
+func (s *recordingSink) Output(text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chunks = append(s.chunks, text)
+}
+
+func (s *recordingSink) Progress(int64, int64) {}
+
+func (s *recordingSink) output() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return strings.Join(s.chunks, "")
+}
+
+func TestChunkWriter(t *testing.T) {
+	sink := &recordingSink{}
+	w := newChunkWriter(sink)
+
+	line := strings.Repeat("x", 99) + "\n"
+	for i := 0; i < 60; i++ {
+		w.Write([]byte(line))
+	}
+	w.Close()
+
+	if got := sink.output(); got != strings.Repeat(line, 60) {
+		t.Errorf("Expected all output to be emitted in order, got %d bytes", len(got))
+	}
+	for i, chunk := range sink.chunks {
+		if len(chunk) > outputChunkSize {
+			t.Errorf("Chunk %d exceeds %d bytes: %d", i, outputChunkSize, len(chunk))
+		}
+		if !strings.HasSuffix(chunk, "\n") {
+			t.Errorf("Chunk %d does not end on a line boundary", i)
+		}
+	}
+}
+
+func TestChunkWriterFlushesWhenIdle(t *testing.T) {
+	sink := &recordingSink{}
+	w := newChunkWriter(sink)
+	defer w.Close()
+
+	w.Write([]byte("started\n"))
+
+	// No further writes: the ticker must emit the buffered output
+	deadline := time.Now().Add(5 * outputFlushInterval)
+	for sink.output() == "" && time.Now().Before(deadline) {
+		time.Sleep(outputFlushInterval / 5)
+	}
+	if got := sink.output(); got != "started\n" {
+		t.Errorf("Expected idle output to be flushed, got %q", got)
+	}
+}
+
+func TestRunProcessStreamsOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+
+	sink := &recordingSink{}
+	ctx := WithOutput(context.Background(), sink)
+	if _, err := runProcess(ctx, t.TempDir(), ArtisanOptions{OutputLimit: 3}, "sh", "-c", "echo first; echo second"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The kept output is capped, the stream is not
+	if got := sink.output(); got != "first\nsecond\n" {
+		t.Errorf("Expected streamed output %q, got %q", "first\nsecond\n", got)
+	}
+}
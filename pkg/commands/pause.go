@@ -0,0 +1,217 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+	"github.com/redis/go-redis/v9"
+)
+
+// Ensure PauseQueueExecutor implements Executor
+var _ Executor = (*PauseQueueExecutor)(nil)
+
+// horizonConfirmTimeout bounds how long we wait for a supervisor to report its new status.
+// Supervisors process pending commands once per loop (about every second).
+const horizonConfirmTimeout = 5 * time.Second
+
+// PauseQueueExecutor handles PAUSE_QUEUE and RESUME_QUEUE commands.
+// Without a program it toggles Laravel's per-queue pause flag (Laravel 11+);
+// with one it pauses or continues that Horizon supervisor instead.
+// Settings detected for the target project override the configured prefixes.
+type PauseQueueExecutor struct {
+	BaseExecutor
+	resume        bool
+	cachePrefix   string
+	connection    string // Default queue connection
+	horizonPrefix string
+	projects      *laravel.Projects
+}
+
+// NewPauseQueueExecutor creates the PAUSE_QUEUE executor
+func NewPauseQueueExecutor(cachePrefix, connection, horizonPrefix string, projects *laravel.Projects) *PauseQueueExecutor {
+	return &PauseQueueExecutor{cachePrefix: cachePrefix, connection: connection, horizonPrefix: horizonPrefix, projects: projects}
+}
+
+// NewResumeQueueExecutor creates the RESUME_QUEUE executor
+func NewResumeQueueExecutor(cachePrefix, connection, horizonPrefix string, projects *laravel.Projects) *PauseQueueExecutor {
+	e := NewPauseQueueExecutor(cachePrefix, connection, horizonPrefix, projects)
+	e.resume = true
+	return e
+}
+
+// SupportedType returns PAUSE_QUEUE or RESUME_QUEUE
+func (e *PauseQueueExecutor) SupportedType() types.CommandType {
+	if e.resume {
+		return types.CmdResumeQueue
+	}
+	return types.CmdPauseQueue
+}
+
+// PauseResult reports the confirmed pause state
+type PauseResult struct {
+	Queue      string `json:"queue,omitempty"`
+	Connection string `json:"connection,omitempty"`
+	Key        string `json:"key,omitempty"`        // Cache key of the pause flag
+	Supervisor string `json:"supervisor,omitempty"` // Horizon supervisor, when one was targeted
+	Paused     bool   `json:"paused"`
+}
+
+// Execute sets or clears the pause state and confirms it by reading it back
+func (e *PauseQueueExecutor) Execute(ctx context.Context, cmd *types.QuasarCommand, redisClient *redis.Client) types.CommandResult {
+	if redisClient == nil {
+		return e.Failed(cmd.ID, "No monitor Redis connection")
+	}
+	if cmd.Payload.Program == "" && cmd.Payload.Queue == "" {
+		return e.Failed(cmd.ID, "Missing queue or program in payload")
+	}
+
+	settings, err := projectSettings(e.projects, cmd.Payload.Project)
+	if err != nil {
+		return e.Failed(cmd.ID, err.Error())
+	}
+
+	if cmd.Payload.Program != "" {
+		horizonPrefix := e.horizonPrefix
+		if settings != nil && settings.HorizonPrefix != "" {
+			// Horizon keeps its state on the queue's Redis database
+			client, release := redisFor(redisClient, settings.Redis.DB)
+			defer release()
+			redisClient, horizonPrefix = client, settings.HorizonPrefix
+		}
+		return e.toggleSupervisor(ctx, cmd.ID, redisClient, horizonPrefix, cmd.Payload.Program)
+	}
+
+	cachePrefix, connection := e.cachePrefix, e.connection
+	if settings != nil {
+		if settings.CacheDriver != "redis" {
+			return e.Failed(cmd.ID, fmt.Sprintf("The project's cache store uses the %s driver; pause flags can only be set in a Redis cache", settings.CacheDriver))
+		}
+		client, release := redisFor(redisClient, settings.CacheRedis.DB)
+		defer release()
+		redisClient = client
+		cachePrefix, connection = settings.PauseCachePrefix(), settings.QueueConnection
+	}
+	if cmd.Payload.Connection != "" {
+		connection = cmd.Payload.Connection
+	}
+	return e.toggleQueue(ctx, cmd.ID, redisClient, cachePrefix, connection, cmd.Payload.Queue)
+}
+
+func (e *PauseQueueExecutor) toggleQueue(ctx context.Context, cmdID string, redisClient *redis.Client, cachePrefix, connection, queue string) types.CommandResult {
+	var err error
+	if e.resume {
+		err = laravel.ResumeQueue(ctx, redisClient, cachePrefix, connection, queue)
+	} else {
+		err = laravel.PauseQueue(ctx, redisClient, cachePrefix, connection, queue)
+	}
+	if err != nil {
+		return e.Failed(cmdID, fmt.Sprintf("Failed to update pause flag of %s: %v", queue, err))
+	}
+
+	paused, err := laravel.IsPaused(ctx, redisClient, cachePrefix, connection, queue)
+	if err != nil {
+		return e.Failed(cmdID, fmt.Sprintf("Pause flag written but could not be confirmed: %v", err))
+	}
+
+	result := PauseResult{
+		Queue:      queue,
+		Connection: connection,
+		Key:        laravel.PauseKey(cachePrefix, connection, queue),
+		Paused:     paused,
+	}
+	if paused == e.resume {
+		return e.FailedWithData(cmdID, fmt.Sprintf("Queue %s:%s is still %s", connection, queue, pausedState(paused)), result)
+	}
+	return e.SuccessWithData(cmdID, fmt.Sprintf("Queue %s:%s %s", connection, queue, pausedState(paused)), result)
+}
+
+func (e *PauseQueueExecutor) toggleSupervisor(ctx context.Context, cmdID string, redisClient *redis.Client, horizonPrefix, program string) types.CommandResult {
+	supervisors, err := laravel.HorizonSupervisors(ctx, redisClient, horizonPrefix)
+	if err != nil {
+		return e.Failed(cmdID, fmt.Sprintf("Failed to read Horizon supervisors: %v", err))
+	}
+	name := findSupervisor(supervisors, program)
+	if name == "" {
+		return e.Failed(cmdID, fmt.Sprintf("No Horizon supervisor matches %s", program))
+	}
+
+	if err := laravel.PauseHorizonSupervisor(ctx, redisClient, horizonPrefix, name, !e.resume); err != nil {
+		return e.Failed(cmdID, fmt.Sprintf("Failed to send command to %s: %v", name, err))
+	}
+
+	expected := "paused"
+	if e.resume {
+		expected = "running"
+	}
+	status, err := e.waitSupervisorStatus(ctx, redisClient, horizonPrefix, name, expected)
+	result := PauseResult{Supervisor: name, Paused: status == "paused"}
+	if err != nil {
+		return e.FailedWithData(cmdID, fmt.Sprintf("Command sent but state could not be confirmed: %v", err), result)
+	}
+	if status != expected {
+		return e.FailedWithData(cmdID, fmt.Sprintf("Supervisor %s is still %s", name, status), result)
+	}
+	return e.SuccessWithData(cmdID, fmt.Sprintf("Supervisor %s confirmed %s", name, status), result)
+}
+
+// waitSupervisorStatus polls Horizon until the supervisor reports the expected status.
+// It returns the last status seen.
+func (e *PauseQueueExecutor) waitSupervisorStatus(ctx context.Context, redisClient *redis.Client, horizonPrefix, name, expected string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, horizonConfirmTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	status := ""
+	for {
+		select {
+		case <-ctx.Done():
+			return status, nil
+		case <-ticker.C:
+		}
+
+		supervisors, err := laravel.HorizonSupervisors(ctx, redisClient, horizonPrefix)
+		if err != nil {
+			if ctx.Err() != nil {
+				return status, nil
+			}
+			return status, err
+		}
+		for _, s := range supervisors {
+			if s.Name == name {
+				status = s.Status
+			}
+		}
+		if status == expected {
+			return status, nil
+		}
+	}
+}
+
+// findSupervisor resolves a supervisor by full name or by the name configured
+// in horizon.php (Horizon prefixes it with the master's name), like horizon:pause-supervisor
+func findSupervisor(supervisors []laravel.HorizonSupervisor, program string) string {
+	for _, s := range supervisors {
+		if s.Name == program {
+			return s.Name
+		}
+	}
+	for _, s := range supervisors {
+		if strings.HasSuffix(s.Name, ":"+program) {
+			return s.Name
+		}
+	}
+	return ""
+}
+
+func pausedState(paused bool) string {
+	if paused {
+		return "paused"
+	}
+	return "resumed"
+}
@@ -0,0 +1,186 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultPeekLimit = 20
+	maxPeekLimit     = 100
+)
+
+// PeekResult is one page of jobs in a queue state
+type PeekResult struct {
+	Queue  string       `json:"queue"`
+	State  string       `json:"state"`
+	Key    string       `json:"key,omitempty"` // Redis key read (empty for Laravel failed jobs)
+	Total  int64        `json:"total"`
+	Offset int          `json:"offset"`
+	Jobs   []JobSummary `json:"jobs"`
+}
+
+// PeekJobsExecutor handles PEEK_JOBS commands (read-only)
+type PeekJobsExecutor struct {
+	BaseExecutor
+	failed   *laravel.FailedStores // Laravel failed job providers by project (optional)
+	projects *laravel.Projects     // Key prefixes of Laravel queues (optional)
+}
+
+// NewPeekJobsExecutor creates a new peek executor
+func NewPeekJobsExecutor(failed *laravel.FailedStores, projects *laravel.Projects) *PeekJobsExecutor {
+	return &PeekJobsExecutor{failed: failed, projects: projects}
+}
+
+// SupportedType returns PEEK_JOBS
+func (e *PeekJobsExecutor) SupportedType() types.CommandType {
+	return types.CmdPeekJobs
+}
+
+// Execute returns a page of decoded job summaries
+func (e *PeekJobsExecutor) Execute(ctx context.Context, cmd *types.QuasarCommand, redisClient *redis.Client) types.CommandResult {
+	queue := cmd.Payload.Queue
+	if queue == "" {
+		return e.Failed(cmd.ID, "Missing queue in payload")
+	}
+
+	state := cmd.Payload.State
+	if state == "" {
+		state = "waiting"
+	}
+
+	offset := cmd.Payload.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	limit := cmd.Payload.Limit
+	if limit <= 0 {
+		limit = defaultPeekLimit
+	}
+	if limit > maxPeekLimit {
+		limit = maxPeekLimit
+	}
+
+	// Laravel failed jobs live in the failed job provider, not the queue
+	if state == "failed" && cmd.Payload.Driver != types.DriverRedis {
+		return e.peekFailed(ctx, cmd.ID, cmd.Payload.Project, queue, offset, limit)
+	}
+	if redisClient == nil {
+		return e.Failed(cmd.ID, "Monitor Redis is not configured")
+	}
+
+	var keyPrefix string
+	if cmd.Payload.Driver != types.DriverRedis {
+		client, prefix, release, err := laravelQueue(e.projects, cmd.Payload.Project, redisClient)
+		if err != nil {
+			return e.Failed(cmd.ID, err.Error())
+		}
+		defer release()
+		redisClient, keyPrefix = client, prefix
+	}
+
+	key, ok := queueKeys(cmd.Payload.Driver, queue, keyPrefix).state(state)
+	if !ok {
+		return e.Failed(cmd.ID, fmt.Sprintf("Unknown state: %s (expected waiting, delayed, reserved or failed)", state))
+	}
+
+	// Laravel keeps delayed and reserved jobs in sorted sets, plain queues may too
+	sorted, err := isSortedSet(ctx, redisClient, key)
+	if err != nil {
+		return e.Failed(cmd.ID, fmt.Sprintf("Failed to read %s: %v", key, err))
+	}
+
+	result := PeekResult{Queue: queue, State: state, Key: key, Offset: offset}
+	start, stop := int64(offset), int64(offset+limit-1)
+
+	if sorted {
+		result.Total, result.Jobs, err = peekSortedSet(ctx, redisClient, key, start, stop)
+	} else {
+		result.Total, result.Jobs, err = peekList(ctx, redisClient, key, start, stop)
+	}
+	if err != nil {
+		return e.Failed(cmd.ID, fmt.Sprintf("Failed to read %s: %v", key, err))
+	}
+
+	return e.SuccessWithData(cmd.ID, fmt.Sprintf("%d of %d jobs in %s", len(result.Jobs), result.Total, key), result)
+}
+
+// peekFailed pages the Laravel failed jobs of a queue, newest first
+func (e *PeekJobsExecutor) peekFailed(ctx context.Context, cmdID, project, queue string, offset, limit int) types.CommandResult {
+	_, store, err := failedJobStore(e.failed, e.projects, project)
+	if err != nil {
+		return e.Failed(cmdID, err.Error())
+	}
+	if store == nil {
+		return e.Failed(cmdID, "No Laravel failed job store configured")
+	}
+
+	jobs, total, err := store.List(ctx, queue, offset, limit)
+	if err != nil {
+		return e.Failed(cmdID, fmt.Sprintf("Failed to list failed jobs: %v", err))
+	}
+
+	result := PeekResult{Queue: queue, State: "failed", Offset: offset, Total: total, Jobs: make([]JobSummary, 0, len(jobs))}
+	for i := range jobs {
+		result.Jobs = append(result.Jobs, summarizeFailedJob(&jobs[i]))
+	}
+
+	return e.SuccessWithData(cmdID, fmt.Sprintf("%d of %d failed jobs of %s", len(result.Jobs), result.Total, queue), result)
+}
+
+// summarizeFailedJob summarizes a failed job record; its jobKey retries it
+func summarizeFailedJob(job *laravel.FailedJob) JobSummary {
+	summary := summarizeJob(job.Payload)
+	summary.JobKey = firstNonEmpty(job.UUID, job.ID)
+	if !job.FailedAt.IsZero() {
+		summary.FailedAt = job.FailedAt.UnixMilli()
+	}
+	summary.Exception, _, _ = strings.Cut(job.Exception, "\n")
+	return summary
+}
+
+func peekList(ctx context.Context, redisClient *redis.Client, key string, start, stop int64) (int64, []JobSummary, error) {
+	total, err := redisClient.LLen(ctx, key).Result()
+	if err != nil {
+		return 0, nil, err
+	}
+	items, err := redisClient.LRange(ctx, key, start, stop).Result()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	jobs := make([]JobSummary, 0, len(items))
+	for _, raw := range items {
+		jobs = append(jobs, summarizeJob(raw))
+	}
+	return total, jobs, nil
+}
+
+// peekSortedSet reads jobs scored by Unix timestamp (Laravel delayed/reserved)
+func peekSortedSet(ctx context.Context, redisClient *redis.Client, key string, start, stop int64) (int64, []JobSummary, error) {
+	total, err := redisClient.ZCard(ctx, key).Result()
+	if err != nil {
+		return 0, nil, err
+	}
+	members, err := redisClient.ZRangeWithScores(ctx, key, start, stop).Result()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	jobs := make([]JobSummary, 0, len(members))
+	for _, m := range members {
+		raw, _ := m.Member.(string)
+		summary := summarizeJob(raw)
+		summary.AvailableAt = unixMillis(m.Score)
+		jobs = append(jobs, summary)
+	}
+	return total, jobs, nil
+}
+
+// Ensure PeekJobsExecutor implements Executor
+var _ Executor = (*PeekJobsExecutor)(nil)
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+)
+
+// memoryFailedStore is a FailedJobStore over a fixed list, oldest first
+type memoryFailedStore struct {
+	jobs []laravel.FailedJob
+}
+
+func (s *memoryFailedStore) Find(_ context.Context, id string) (*laravel.FailedJob, error) {
+	for i := range s.jobs {
+		if s.jobs[i].ID == id || s.jobs[i].UUID == id {
+			return &s.jobs[i], nil
+		}
+	}
+	return nil, laravel.ErrFailedJobNotFound
+}
+
+func (s *memoryFailedStore) IDs(context.Context) ([]string, error) {
+	ids := make([]string, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids, nil
+}
+
+func (s *memoryFailedStore) List(_ context.Context, queue string, offset, limit int) ([]laravel.FailedJob, int64, error) {
+	var matching []laravel.FailedJob
+	for i := len(s.jobs) - 1; i >= 0; i-- {
+		if s.jobs[i].Queue == queue {
+			matching = append(matching, s.jobs[i])
+		}
+	}
+	if offset >= len(matching) {
+		return nil, int64(len(matching)), nil
+	}
+	return matching[offset:min(offset+limit, len(matching))], int64(len(matching)), nil
+}
+
+func (s *memoryFailedStore) Forget(_ context.Context, id string) error {
+	s.jobs = slices.DeleteFunc(s.jobs, func(job laravel.FailedJob) bool { return job.ID == id })
+	return nil
+}
+
+func (s *memoryFailedStore) Close() error { return nil }
+
+func TestPeekFailedJobs(t *testing.T) {
+	failedAt := time.Unix(1700000000, 0)
+	store := &memoryFailedStore{}
+	for i, queue := range []string{"default", "emails", "default", "default"} {
+		store.jobs = append(store.jobs, laravel.FailedJob{
+			ID:        string(rune('1' + i)),
+			UUID:      "uuid-" + string(rune('1'+i)),
+			Queue:     queue,
+			Payload:   `{"uuid":"uuid-` + string(rune('1'+i)) + `","displayName":"App\\Jobs\\SendMail","attempts":3}`,
+			Exception: "RuntimeException: boom\n#0 {main}",
+			FailedAt:  failedAt,
+		})
+	}
+	blog := &memoryFailedStore{jobs: []laravel.FailedJob{{ID: "9", Queue: "default", Payload: `{}`}}}
+	projects := laravel.NewProjects([]laravel.Project{
+		{Name: "shop", Root: "/srv/shop"},
+		{Name: "blog", Root: "/srv/blog"},
+	}, nil)
+	stores := laravel.NewFailedStores(func(project laravel.Project) (laravel.FailedJobStore, error) {
+		if project.Name == "blog" {
+			return blog, nil
+		}
+		return store, nil
+	})
+	executor := NewPeekJobsExecutor(stores, projects)
+
+	cmd := &types.QuasarCommand{ID: "cmd-1", Payload: types.CommandPayload{Project: "shop", Queue: "default", State: "failed", Offset: 1, Limit: 1}}
+	result := executor.Execute(context.Background(), cmd, nil)
+	if result.Status != types.StatusSuccess {
+		t.Fatalf("Expected success, got %s: %s", result.Status, result.Message)
+	}
+
+	page := result.Data.(PeekResult)
+	if page.Total != 3 || len(page.Jobs) != 1 {
+		t.Fatalf("Expected 1 of 3 failed jobs, got %d of %d", len(page.Jobs), page.Total)
+	}
+
+	// Newest first: job 4, then job 3
+	job := page.Jobs[0]
+	if job.JobKey != "uuid-3" || job.DisplayName != `App\Jobs\SendMail` || job.Attempts != 3 {
+		t.Errorf("Unexpected summary: %+v", job)
+	}
+	if job.FailedAt != failedAt.UnixMilli() || job.Exception != "RuntimeException: boom" {
+		t.Errorf("Expected failure time and first exception line, got %d %q", job.FailedAt, job.Exception)
+	}
+
+	cmd.Payload.Project = ""
+	if result := executor.Execute(context.Background(), cmd, nil); result.Status != types.StatusFailed {
+		t.Errorf("Expected failure without a project on a host with several, got %s", result.Status)
+	}
+
+	executor = NewPeekJobsExecutor(nil, projects)
+	if result := executor.Execute(context.Background(), cmd, nil); result.Status != types.StatusFailed {
+		t.Errorf("Expected failure without a failed job store, got %s", result.Status)
+	}
+}
@@ -2,9 +2,11 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
 
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/gravito-framework/quasar-go/pkg/sidekiq"
 	"github.com/gravito-framework/quasar-go/pkg/types"
 	"github.com/redis/go-redis/v9"
 )
@@ -12,11 +14,21 @@ import (
 // RetryJobExecutor handles RETRY_JOB commands
 type RetryJobExecutor struct {
 	BaseExecutor
+	failed   *laravel.FailedStores // Laravel failed job providers by project (optional)
+	projects *laravel.Projects     // Key prefixes of Laravel queues (optional)
+	sidekiq  sidekiqTarget
 }
 
 // NewRetryJobExecutor creates a new retry executor
-func NewRetryJobExecutor() *RetryJobExecutor {
-	return &RetryJobExecutor{}
+func NewRetryJobExecutor(failed *laravel.FailedStores, projects *laravel.Projects) *RetryJobExecutor {
+	return &RetryJobExecutor{failed: failed, projects: projects}
+}
+
+// WithSidekiq sets the Redis client (nil = monitor client) and namespace of
+// Sidekiq jobs, and the namespaces of queues that have their own
+func (e *RetryJobExecutor) WithSidekiq(client *redis.Client, namespace string, queueNamespaces map[string]string) *RetryJobExecutor {
+	e.sidekiq = newSidekiqTarget(client, namespace, queueNamespaces)
+	return e
 }
 
 // SupportedType returns RETRY_JOB
@@ -30,69 +42,77 @@ func (e *RetryJobExecutor) Execute(ctx context.Context, cmd *types.QuasarCommand
 	jobKey := cmd.Payload.JobKey
 	driver := cmd.Payload.Driver
 
-	if queue == "" || jobKey == "" {
+	// Laravel and Sidekiq failed jobs know their own queue
+	if jobKey == "" || (queue == "" && driver == types.DriverRedis) {
 		return e.Failed(cmd.ID, "Missing queue or jobKey in payload")
 	}
 
 	switch driver {
+	case types.DriverSidekiq:
+		return e.retrySidekiqJob(ctx, cmd.ID, redisClient, queue, cmd.Payload.State, jobKey)
 	case types.DriverRedis:
 		return e.retryRedisJob(ctx, cmd.ID, redisClient, queue, jobKey)
 	default:
 		// Default to Laravel pattern
-		return e.retryLaravelJob(ctx, cmd.ID, redisClient, queue, jobKey)
+		return e.retryLaravelJob(ctx, cmd.ID, redisClient, cmd.Payload.Project, jobKey)
 	}
 }
 
-// retryRedisJob moves job from {queue}:failed -> {queue}
+// retryRedisJob atomically moves job from {queue}:failed -> {queue}
 func (e *RetryJobExecutor) retryRedisJob(ctx context.Context, cmdID string, redisClient *redis.Client, queue, jobKey string) types.CommandResult {
-	failedKey := queue + ":failed"
-	waitingKey := queue
+	keys := queueKeys(types.DriverRedis, queue, "")
 
-	// Get all jobs from failed list
-	jobs, err := redisClient.LRange(ctx, failedKey, 0, -1).Result()
+	payload, err := takeJob(ctx, redisClient, keys.failed, keys.waiting, false, jobKey)
 	if err != nil {
-		return e.Failed(cmdID, fmt.Sprintf("Failed to read failed queue: %v", err))
+		return e.Failed(cmdID, fmt.Sprintf("Failed to move job: %v", err))
 	}
-
-	// Find the job
-	jobIndex := -1
-	var foundJob string
-	for i, job := range jobs {
-		if strings.Contains(job, jobKey) || job == jobKey {
-			jobIndex = i
-			foundJob = job
-			break
-		}
+	if payload == "" {
+		return e.Failed(cmdID, fmt.Sprintf("Job not found in %s", keys.failed))
 	}
 
-	if jobIndex == -1 {
-		return e.Failed(cmdID, fmt.Sprintf("Job not found in %s", failedKey))
+	return e.Success(cmdID, fmt.Sprintf("Job moved to %s", keys.waiting))
+}
+
+// retryLaravelJob retries a failed Laravel job natively; jobKey is the failed job ID or UUID
+// from the project's own failed job store
+func (e *RetryJobExecutor) retryLaravelJob(ctx context.Context, cmdID string, redisClient *redis.Client, name, jobKey string) types.CommandResult {
+	project, store, err := failedJobStore(e.failed, e.projects, name)
+	if err != nil {
+		return e.Failed(cmdID, err.Error())
 	}
+	if store == nil {
+		return e.Failed(cmdID, "No Laravel failed job store configured")
+	}
+	clients := newRedisClients(redisClient)
+	defer clients.close()
+	return retryFailedJob(ctx, &e.BaseExecutor, cmdID, store, project.Settings, clients, jobKey)
+}
 
-	// Atomic move: LREM + RPUSH
-	pipe := redisClient.TxPipeline()
-	pipe.LRem(ctx, failedKey, 1, foundJob)
-	pipe.RPush(ctx, waitingKey, foundJob)
-	_, err = pipe.Exec(ctx)
+// retryFailedJob pushes one Laravel failed job back onto the queue of the
+// connection it failed on
+func retryFailedJob(ctx context.Context, e *BaseExecutor, cmdID string, store laravel.FailedJobStore, settings *laravel.Settings, clients *redisClients, id string) types.CommandResult {
+	job, err := laravel.Retry(ctx, store, settings, clients.get, id)
+	if errors.Is(err, laravel.ErrFailedJobNotFound) {
+		return e.Failed(cmdID, fmt.Sprintf("Failed job %s not found", id))
+	}
 	if err != nil {
-		return e.Failed(cmdID, fmt.Sprintf("Failed to move job: %v", err))
+		return e.Failed(cmdID, fmt.Sprintf("Failed to retry job %s: %v", id, err))
 	}
 
-	return e.Success(cmdID, fmt.Sprintf("Job moved to %s", waitingKey))
+	conn, _ := settings.QueueRedis(job.Connection)
+	return e.Success(cmdID, fmt.Sprintf("Job %s pushed to %squeues:%s (connection %s)", id, conn.Prefix, job.Queue, firstNonEmpty(job.Connection, settings.QueueConnection)))
 }
 
-// retryLaravelJob pushes job back to Laravel queue
-func (e *RetryJobExecutor) retryLaravelJob(ctx context.Context, cmdID string, redisClient *redis.Client, queue, jobKey string) types.CommandResult {
-	prefix := "queues"
-	waitingKey := prefix + ":" + queue
-
-	// Push the job back (jobKey should be the serialized job data)
-	err := redisClient.RPush(ctx, waitingKey, jobKey).Err()
+// retrySidekiqJob enqueues a job from Sidekiq's retry or dead set again; jobKey is its jid
+func (e *RetryJobExecutor) retrySidekiqJob(ctx context.Context, cmdID string, redisClient *redis.Client, queue, state, jid string) types.CommandResult {
+	job, ns, set, err := e.sidekiq.run(ctx, redisClient, queue, state, jid, sidekiq.Retry)
+	if errors.Is(err, sidekiq.ErrJobNotFound) {
+		return e.Failed(cmdID, fmt.Sprintf("Job %s not found in the Sidekiq retry or dead set", jid))
+	}
 	if err != nil {
-		return e.Failed(cmdID, fmt.Sprintf("Failed to push job: %v", err))
+		return e.Failed(cmdID, fmt.Sprintf("Failed to retry job %s: %v", jid, err))
 	}
-
-	return e.Success(cmdID, fmt.Sprintf("Job pushed to %s", waitingKey))
+	return e.Success(cmdID, fmt.Sprintf("Job %s (%s) moved from %s to %s", jid, job.Class, ns.Key(set), ns.Queue(job.Queue)))
 }
 
 // Ensure RetryJobExecutor implements Executor
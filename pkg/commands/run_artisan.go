@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/gravito-framework/quasar-go/pkg/config"
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+	"github.com/redis/go-redis/v9"
+)
+
+// Ensure RunArtisanExecutor implements Executor
+var _ Executor = (*RunArtisanExecutor)(nil)
+
+// Audit event types for RUN_ARTISAN
+const (
+	EventArtisanStarted = "artisan.started"
+	EventArtisanRun     = "artisan.run"
+	EventArtisanDenied  = "artisan.denied"
+)
+
+// RunArtisanExecutor handles RUN_ARTISAN commands. Only commands on the
+// local allowlist run, and every attempt is recorded as an audit event.
+type RunArtisanExecutor struct {
+	BaseExecutor
+	projects *laravel.Projects
+	rules    []artisanRule
+	options  ArtisanOptions
+	audit    func(types.Event) // Optional
+}
+
+// artisanRule is a compiled config.ArtisanRule
+type artisanRule struct {
+	command string
+	args    []*regexp.Regexp
+}
+
+// NewRunArtisanExecutor creates a new artisan runner. Rules with invalid
+// patterns are logged and skipped.
+func NewRunArtisanExecutor(rules []config.ArtisanRule, projects *laravel.Projects, options ArtisanOptions, audit func(types.Event)) *RunArtisanExecutor {
+	e := &RunArtisanExecutor{
+		projects: projects,
+		options:  options,
+		audit:    audit,
+	}
+
+	for _, rule := range rules {
+		compiled := artisanRule{command: rule.Command}
+		for _, pattern := range rule.Args {
+			re, err := regexp.Compile("^(?:" + pattern + ")$")
+			if err != nil {
+				slog.Warn("Skipping artisan allowlist rule with invalid pattern", "command", rule.Command, "pattern", pattern, "error", err)
+				compiled.command = ""
+				break
+			}
+			compiled.args = append(compiled.args, re)
+		}
+		if compiled.command != "" {
+			e.rules = append(e.rules, compiled)
+		}
+	}
+	return e
+}
+
+// SupportedType returns RUN_ARTISAN
+func (e *RunArtisanExecutor) SupportedType() types.CommandType {
+	return types.CmdRunArtisan
+}
+
+// Execute runs an allowlisted artisan command in the target project
+func (e *RunArtisanExecutor) Execute(ctx context.Context, cmd *types.QuasarCommand, _ *redis.Client) types.CommandResult {
+	command := cmd.Payload.Command
+	args := cmd.Payload.Args
+	if command == "" {
+		return e.Failed(cmd.ID, "Missing command in payload")
+	}
+
+	if err := e.allow(command, args); err != nil {
+		e.record(types.NewEvent(EventArtisanDenied, types.SeverityWarning,
+			fmt.Sprintf("Artisan %s denied: %v", command, err), auditData(cmd)))
+		return e.Failed(cmd.ID, fmt.Sprintf("Artisan command not allowed: %v", err))
+	}
+
+	project, err := e.projects.Resolve(cmd.Payload.Project)
+	if err != nil {
+		return e.Failed(cmd.ID, fmt.Sprintf("Laravel project not found: %v", err))
+	}
+
+	data := auditData(cmd)
+	data["project"] = project.Name
+	data["root"] = project.Root
+	e.record(types.NewEvent(EventArtisanStarted, types.SeverityInfo,
+		fmt.Sprintf("Artisan %s started in %s", command, project.Name), data))
+
+	result, runErr := runArtisan(ctx, project, e.options, append([]string{command}, args...)...)
+
+	data = auditData(cmd)
+	data["project"] = project.Name
+	data["root"] = project.Root
+	data["exitCode"] = result.ExitCode
+	data["durationMs"] = result.DurationMs
+	data["timedOut"] = result.TimedOut
+	severity := types.SeverityInfo
+	if runErr != nil || result.ExitCode != 0 {
+		severity = types.SeverityWarning
+	}
+	e.record(types.NewEvent(EventArtisanRun, severity,
+		fmt.Sprintf("Artisan %s exited with code %d after %dms", command, result.ExitCode, result.DurationMs), data))
+
+	return artisanCommandResult(&e.BaseExecutor, cmd.ID, result, runErr)
+}
+
+// allow checks a command against the allowlist. A command may be listed
+// several times; it is allowed if any of its rules accepts all arguments.
+func (e *RunArtisanExecutor) allow(command string, args []string) error {
+	var denied error
+	for _, rule := range e.rules {
+		if rule.command != command {
+			continue
+		}
+		arg, ok := rule.allowsArgs(args)
+		if ok {
+			return nil
+		}
+		if denied == nil {
+			denied = fmt.Errorf("argument %q of %s does not match the allowlist", arg, command)
+		}
+	}
+	if denied != nil {
+		return denied
+	}
+	return fmt.Errorf("%s is not on the allowlist", command)
+}
+
+// allowsArgs reports whether every argument matches a pattern of the rule,
+// and otherwise the first one that doesn't
+func (r artisanRule) allowsArgs(args []string) (string, bool) {
+	for _, arg := range args {
+		if !r.allowsArg(arg) {
+			return arg, false
+		}
+	}
+	return "", true
+}
+
+func (r artisanRule) allowsArg(arg string) bool {
+	for _, re := range r.args {
+		if re.MatchString(arg) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *RunArtisanExecutor) record(event types.Event) {
+	if e.audit != nil {
+		e.audit(event)
+	}
+}
+
+// auditData describes who asked for what
+func auditData(cmd *types.QuasarCommand) map[string]interface{} {
+	return map[string]interface{}{
+		"commandId": cmd.ID,
+		"issuer":    cmd.Issuer,
+		"command":   cmd.Payload.Command,
+		"args":      strings.Join(cmd.Payload.Args, " "),
+	}
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/gravito-framework/quasar-go/pkg/config"
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+)
+
+func TestRunArtisanAllow(t *testing.T) {
+	e := NewRunArtisanExecutor([]config.ArtisanRule{
+		{Command: "cache:clear"},
+		{Command: "queue:retry", Args: []string{"[0-9a-f-]+|all"}},
+		{Command: "app:prune", Args: []string{"--days=[0-9]+", "--force"}},
+		{Command: "broken", Args: []string{"(["}},
+		{Command: "queue:work", Args: []string{"--once"}},
+		{Command: "queue:work", Args: []string{"--queue=[a-z]+", "--stop-when-empty"}},
+	}, laravel.NewProjects(nil, nil), DefaultArtisanOptions(), nil)
+
+	tests := []struct {
+		name    string
+		command string
+		args    []string
+		allowed bool
+	}{
+		{"no args", "cache:clear", nil, true},
+		{"args not allowed", "cache:clear", []string{"--store=file"}, false},
+		{"matching arg", "queue:retry", []string{"all"}, true},
+		{"pattern is anchored", "queue:retry", []string{"all;rm"}, false},
+		{"options in any order", "app:prune", []string{"--force", "--days=30"}, true},
+		{"one bad option", "app:prune", []string{"--days=30", "--env=production"}, false},
+		{"not listed", "migrate:fresh", nil, false},
+		{"invalid rule skipped", "broken", nil, false},
+		{"first of several rules", "queue:work", []string{"--once"}, true},
+		{"later rule for the same command", "queue:work", []string{"--queue=emails", "--stop-when-empty"}, true},
+		{"no rule matches", "queue:work", []string{"--once", "--stop-when-empty"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := e.allow(tt.command, tt.args)
+			if (err == nil) != tt.allowed {
+				t.Errorf("Expected allowed=%v, got error %v", tt.allowed, err)
+			}
+		})
+	}
+}
+
+func TestRunArtisanDeniedIsAudited(t *testing.T) {
+	var events []types.Event
+	e := NewRunArtisanExecutor(nil, laravel.NewProjects(nil, nil), DefaultArtisanOptions(), func(event types.Event) {
+		events = append(events, event)
+	})
+
+	cmd := &types.QuasarCommand{ID: "cmd-1", Issuer: "ops", Payload: types.CommandPayload{Command: "migrate:fresh"}}
+	result := e.Execute(context.Background(), cmd, nil)
+
+	if result.Status != types.StatusFailed {
+		t.Errorf("Expected failed result, got %s", result.Status)
+	}
+	if len(events) != 1 || events[0].Type != EventArtisanDenied {
+		t.Fatalf("Expected one %s event, got %+v", EventArtisanDenied, events)
+	}
+	if events[0].Data["issuer"] != "ops" {
+		t.Errorf("Expected issuer ops, got %v", events[0].Data["issuer"])
+	}
+}
+
+func TestRunArtisanAuditsStartAndFinish(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "artisan"), []byte("echo cleared\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	projects := laravel.NewProjects([]laravel.Project{{Name: "app", Root: root, PHP: "sh"}}, nil)
+
+	var events []types.Event
+	e := NewRunArtisanExecutor([]config.ArtisanRule{{Command: "cache:clear"}}, projects, DefaultArtisanOptions(), func(event types.Event) {
+		events = append(events, event)
+	})
+
+	cmd := &types.QuasarCommand{ID: "cmd-1", Issuer: "ops", Payload: types.CommandPayload{Command: "cache:clear"}}
+	result := e.Execute(context.Background(), cmd, nil)
+
+	if result.Status != types.StatusSuccess {
+		t.Fatalf("Expected success, got %s: %s", result.Status, result.Message)
+	}
+	if len(events) != 2 || events[0].Type != EventArtisanStarted || events[1].Type != EventArtisanRun {
+		t.Fatalf("Expected %s then %s events, got %+v", EventArtisanStarted, EventArtisanRun, events)
+	}
+	if events[0].Data["project"] != "app" {
+		t.Errorf("Expected project app, got %v", events[0].Data["project"])
+	}
+}
@@ -0,0 +1,286 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/gravito-framework/quasar-go/pkg/probes"
+	"github.com/gravito-framework/quasar-go/pkg/supervisor"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+	"github.com/gravito-framework/quasar-go/pkg/workers"
+	"github.com/redis/go-redis/v9"
+)
+
+// Ensure ScaleWorkersExecutor implements Executor
+var _ Executor = (*ScaleWorkersExecutor)(nil)
+
+// ScaleResult reports the effect of a SCALE_WORKERS command
+type ScaleResult struct {
+	Queue   string `json:"queue"`
+	Mode    string `json:"mode"` // "supervisor" or "managed"
+	Before  int    `json:"before"`
+	After   int    `json:"after"`
+	Started []int  `json:"started"`
+	Stopped []int  `json:"stopped"`
+}
+
+// ScaleWorkersExecutor handles SCALE_WORKERS commands.
+// With a Supervisor program in the payload it rewrites numprocs of that
+// program; otherwise it uses the built-in worker manager.
+type ScaleWorkersExecutor struct {
+	BaseExecutor
+	projects         *laravel.Projects
+	manager          *workers.Manager
+	supervisor       *supervisor.Client // Optional
+	supervisorConfig []string           // Config file globs holding [program:x] sections
+}
+
+// NewScaleWorkersExecutor creates a new scale executor
+func NewScaleWorkersExecutor(manager *workers.Manager, supervisorClient *supervisor.Client, supervisorConfig []string, projects *laravel.Projects) *ScaleWorkersExecutor {
+	return &ScaleWorkersExecutor{
+		projects:         projects,
+		manager:          manager,
+		supervisor:       supervisorClient,
+		supervisorConfig: supervisorConfig,
+	}
+}
+
+// SupportedType returns SCALE_WORKERS
+func (e *ScaleWorkersExecutor) SupportedType() types.CommandType {
+	return types.CmdScaleWorkers
+}
+
+// Execute changes the number of workers serving a queue
+func (e *ScaleWorkersExecutor) Execute(ctx context.Context, cmd *types.QuasarCommand, _ *redis.Client) types.CommandResult {
+	queue := cmd.Payload.Queue
+	if queue == "" || cmd.Payload.Count == nil {
+		return e.Failed(cmd.ID, "Missing queue or count in payload")
+	}
+	target := *cmd.Payload.Count
+	if target < 0 {
+		return e.Failed(cmd.ID, "Count must not be negative")
+	}
+
+	if cmd.Payload.Program != "" {
+		if e.supervisor == nil {
+			return e.Failed(cmd.ID, "Supervisor integration is not configured (set QUASAR_SUPERVISOR_URL)")
+		}
+		return e.scaleSupervisor(ctx, cmd.ID, queue, cmd.Payload.Program, target)
+	}
+
+	return e.scaleManaged(ctx, cmd.ID, cmd.Payload.Project, queue, cmd.Payload.Connection, target)
+}
+
+// scaleConfirmTimeout bounds the wait for a rescaled Supervisor group to settle
+const scaleConfirmTimeout = 60 * time.Second
+
+// scaleSupervisor sets numprocs of a Supervisor program and applies it like
+// `supervisorctl update`, so the new size survives autostart and later updates.
+// Changing numprocs restarts the group's processes.
+func (e *ScaleWorkersExecutor) scaleSupervisor(ctx context.Context, cmdID, queue, group string, target int) types.CommandResult {
+	if target == 0 {
+		return e.Failed(cmdID, "Supervisor programs run at least one process; use SUPERVISOR_ACTION stop-group instead")
+	}
+
+	infos, err := e.groupProcesses(ctx, group)
+	if err != nil {
+		return e.Failed(cmdID, fmt.Sprintf("Failed to read Supervisor state: %v", err))
+	}
+	if len(infos) == 0 {
+		return e.Failed(cmdID, fmt.Sprintf("Supervisor group %s not found", group))
+	}
+
+	result := &ScaleResult{Queue: queue, Mode: "supervisor"}
+	beforePIDs := runningPIDs(infos)
+	result.Before = len(beforePIDs)
+
+	file, previous, err := supervisor.SetNumprocs(e.supervisorConfig, group, target)
+	if err != nil {
+		return e.FailedWithData(cmdID, fmt.Sprintf("Failed to set numprocs of %s: %v", group, err), result)
+	}
+	if previous != target {
+		// The group is restarted with the new size; these exits are intended
+		for _, pid := range beforePIDs {
+			probes.ExpectWorkerExit(int32(pid))
+		}
+	}
+
+	var errs []error
+	if _, err := e.supervisor.UpdateGroup(ctx, group); err != nil {
+		return e.FailedWithData(cmdID, fmt.Sprintf("Set numprocs=%d in %s but the update failed: %v", target, file, err), result)
+	}
+	// Programs without autostart (or stopped by hand) are started explicitly
+	if err := e.supervisor.StartProcessGroup(ctx, group); err != nil {
+		errs = append(errs, err)
+	}
+
+	after, err := e.waitForGroup(ctx, group, target)
+	if err != nil {
+		return e.FailedWithData(cmdID, fmt.Sprintf("Scaled but state could not be confirmed: %v", err), result)
+	}
+	afterPIDs := runningPIDs(after)
+	result.After = len(afterPIDs)
+	result.Started, result.Stopped = diffPIDs(beforePIDs, afterPIDs)
+
+	msg := fmt.Sprintf("Scaled %s (%s) from %d to %d workers (numprocs %d -> %d in %s)",
+		queue, group, result.Before, result.After, previous, target, file)
+	if result.After != target {
+		if len(errs) > 0 {
+			msg += fmt.Sprintf(". Errors: %v", errs)
+		}
+		return e.FailedWithData(cmdID, msg, result)
+	}
+	return e.SuccessWithData(cmdID, msg, result)
+}
+
+// waitForGroup polls until the group runs the target number of processes and
+// none is still starting, or the timeout passes
+func (e *ScaleWorkersExecutor) waitForGroup(ctx context.Context, group string, target int) ([]supervisor.ProcessInfo, error) {
+	deadline := time.Now().Add(scaleConfirmTimeout)
+	for {
+		infos, err := e.groupProcesses(ctx, group)
+		if err != nil {
+			return nil, err
+		}
+		starting := false
+		for _, info := range infos {
+			if info.State == "STARTING" || info.State == "BACKOFF" {
+				starting = true
+			}
+		}
+		if (!starting && len(runningPIDs(infos)) == target) || !time.Now().Before(deadline) || ctx.Err() != nil {
+			return infos, nil
+		}
+		sleepCtx(ctx, workerPollInterval)
+	}
+}
+
+// scaleManaged starts or stops agent-managed queue:work processes
+func (e *ScaleWorkersExecutor) scaleManaged(ctx context.Context, cmdID, projectName, queue, connection string, target int) types.CommandResult {
+	if e.manager == nil {
+		return e.Failed(cmdID, "Built-in worker manager is not available")
+	}
+
+	project, err := e.projects.Resolve(projectName)
+	if err != nil {
+		return e.Failed(cmdID, fmt.Sprintf("Laravel project not found: %v", err))
+	}
+	root := project.Root
+
+	result := &ScaleResult{Queue: queue, Mode: "managed"}
+	beforePIDs, err := queueWorkerPIDs(ctx, root, queue)
+	if err != nil {
+		return e.Failed(cmdID, fmt.Sprintf("Failed to list processes: %v", err))
+	}
+	result.Before = len(beforePIDs)
+
+	var errs []error
+	if target > result.Before {
+		for i := result.Before; i < target; i++ {
+			if _, err := e.manager.Start(workers.Spec{Root: root, PHP: project.PHP, Connection: connection, Queue: queue}); err != nil {
+				errs = append(errs, err)
+				break
+			}
+		}
+	} else if target < result.Before {
+		// Only agent-managed workers can be stopped; others belong to Supervisor/systemd
+		managed := e.manager.List(root, queue)
+		toStop := result.Before - target
+		for i := len(managed) - 1; i >= 0 && toStop > 0; i-- {
+			probes.ExpectWorkerExit(int32(managed[i].PID))
+			if err := e.manager.Stop(managed[i].PID); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			toStop--
+		}
+		if toStop > 0 {
+			errs = append(errs, fmt.Errorf("%d workers are not managed by the agent", toStop))
+		}
+	}
+
+	afterPIDs, err := queueWorkerPIDs(ctx, root, queue)
+	if err != nil {
+		return e.FailedWithData(cmdID, fmt.Sprintf("Scaled but state could not be confirmed: %v", err), result)
+	}
+	result.After = len(afterPIDs)
+	result.Started, result.Stopped = diffPIDs(beforePIDs, afterPIDs)
+
+	msg := fmt.Sprintf("Scaled %s from %d to %d workers", queue, result.Before, result.After)
+	if result.After != target {
+		if len(errs) > 0 {
+			msg += fmt.Sprintf(". Errors: %v", errs)
+		}
+		return e.FailedWithData(cmdID, msg, result)
+	}
+	return e.SuccessWithData(cmdID, msg, result)
+}
+
+// groupProcesses returns the processes of a Supervisor group, sorted by name
+func (e *ScaleWorkersExecutor) groupProcesses(ctx context.Context, group string) ([]supervisor.ProcessInfo, error) {
+	infos, err := e.supervisor.GetAllProcessInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []supervisor.ProcessInfo
+	for _, info := range infos {
+		if info.Group == group {
+			result = append(result, info)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// queueWorkerPIDs lists local workers of a project that serve a queue.
+// It scans processes directly so lifecycle tracking is not advanced mid-tick.
+func queueWorkerPIDs(ctx context.Context, root, queue string) ([]int, error) {
+	workers, err := listWorkers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pids []int
+	for _, w := range workersInRoot(workers, root) {
+		if slices.Contains(w.queues, queue) {
+			pids = append(pids, int(w.pid))
+		}
+	}
+	return pids, nil
+}
+
+func runningPIDs(infos []supervisor.ProcessInfo) []int {
+	var pids []int
+	for _, info := range infos {
+		if info.State == "RUNNING" && info.PID > 0 {
+			pids = append(pids, info.PID)
+		}
+	}
+	return pids
+}
+
+// diffPIDs returns PIDs only present after (started) and only present before (stopped)
+func diffPIDs(before, after []int) (started, stopped []int) {
+	inBefore := make(map[int]bool, len(before))
+	for _, pid := range before {
+		inBefore[pid] = true
+	}
+	inAfter := make(map[int]bool, len(after))
+	for _, pid := range after {
+		inAfter[pid] = true
+		if !inBefore[pid] {
+			started = append(started, pid)
+		}
+	}
+	for _, pid := range before {
+		if !inAfter[pid] {
+			stopped = append(stopped, pid)
+		}
+	}
+	return started, stopped
+}
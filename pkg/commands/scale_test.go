@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gravito-framework/quasar-go/pkg/supervisor"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+)
+
+// fakeSupervisor runs a program group whose size follows numprocs in its config file
+type fakeSupervisor struct {
+	mu       sync.Mutex
+	config   string
+	numprocs int // Active (loaded) numprocs
+	running  bool
+	nextPID  int
+	calls    []string
+}
+
+var numprocsPattern = regexp.MustCompile(`(?m)^numprocs\s*=\s*(\d+)`)
+
+func (f *fakeSupervisor) configured() int {
+	data, _ := os.ReadFile(f.config)
+	m := numprocsPattern.FindSubmatch(data)
+	if m == nil {
+		return 1
+	}
+	n, _ := strconv.Atoi(string(m[1]))
+	return n
+}
+
+func (f *fakeSupervisor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	var call struct {
+		Method string `xml:"methodName"`
+	}
+	_ = xml.Unmarshal(body, &call)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, call.Method)
+
+	ok := `<?xml version="1.0"?><methodResponse><params><param><value><boolean>1</boolean></value></param></params></methodResponse>`
+	switch call.Method {
+	case "supervisor.getAllProcessInfo":
+		var members strings.Builder
+		for i := 0; i < f.numprocs; i++ {
+			state, pid := "STOPPED", 0
+			if f.running {
+				state, pid = "RUNNING", f.nextPID+i
+			}
+			fmt.Fprintf(&members, `<value><struct>
+<member><name>name</name><value><string>worker_%02d</string></value></member>
+<member><name>group</name><value><string>laravel-worker</string></value></member>
+<member><name>statename</name><value><string>%s</string></value></member>
+<member><name>pid</name><value><int>%d</int></value></member>
+</struct></value>`, i, state, pid)
+		}
+		fmt.Fprintf(w, `<?xml version="1.0"?><methodResponse><params><param><value><array><data>%s</data></array></value></param></params></methodResponse>`, members.String())
+	case "supervisor.reloadConfig":
+		changed := ""
+		if f.configured() != f.numprocs {
+			changed = `<value><string>laravel-worker</string></value>`
+		}
+		fmt.Fprintf(w, `<?xml version="1.0"?><methodResponse><params><param><value><array><data><value><array><data>
+<value><array><data></data></array></value>
+<value><array><data>%s</data></array></value>
+<value><array><data></data></array></value>
+</data></array></value></data></array></value></param></params></methodResponse>`, changed)
+	case "supervisor.stopProcessGroup":
+		f.running = false
+		fmt.Fprint(w, ok)
+	case "supervisor.removeProcessGroup":
+		f.numprocs = 0
+		fmt.Fprint(w, ok)
+	case "supervisor.addProcessGroup":
+		// autostart=true
+		f.numprocs = f.configured()
+		f.running = true
+		f.nextPID += 100
+		fmt.Fprint(w, ok)
+	case "supervisor.startProcessGroup":
+		f.running = true
+		fmt.Fprint(w, ok)
+	default:
+		http.Error(w, "unexpected method "+call.Method, http.StatusBadRequest)
+	}
+}
+
+func TestScaleSupervisorRewritesNumprocs(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "worker.conf")
+	err := os.WriteFile(config, []byte("[program:laravel-worker]\nprocess_name=%(program_name)s_%(process_num)02d\nnumprocs=2\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake := &fakeSupervisor{config: config, numprocs: 2, running: true, nextPID: 100}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client, err := supervisor.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	executor := NewScaleWorkersExecutor(nil, client, []string{config}, nil)
+
+	scale := func(count int) types.CommandResult {
+		cmd := &types.QuasarCommand{ID: "cmd-1", Payload: types.CommandPayload{Queue: "default", Program: "laravel-worker", Count: &count}}
+		return executor.Execute(context.Background(), cmd, nil)
+	}
+
+	// Above the old numprocs
+	result := scale(4)
+	if result.Status != types.StatusSuccess {
+		t.Fatalf("Expected success, got %s: %s", result.Status, result.Message)
+	}
+	data := result.Data.(*ScaleResult)
+	if data.Before != 2 || data.After != 4 {
+		t.Errorf("Expected 2 -> 4 workers, got %d -> %d", data.Before, data.After)
+	}
+	if !reflect.DeepEqual(data.Started, []int{200, 201, 202, 203}) || !reflect.DeepEqual(data.Stopped, []int{100, 101}) {
+		t.Errorf("Expected the group to restart with new PIDs, got started %v stopped %v", data.Started, data.Stopped)
+	}
+	if n := fake.configured(); n != 4 {
+		t.Errorf("Expected numprocs=4 in config, got %d", n)
+	}
+
+	// Down, and the new size survives another update
+	if result := scale(1); result.Status != types.StatusSuccess {
+		t.Fatalf("Expected success, got %s: %s", result.Status, result.Message)
+	}
+	if changed, err := client.UpdateGroup(context.Background(), "laravel-worker"); err != nil || changed {
+		t.Errorf("Expected no pending change after scaling, got %v, %v", changed, err)
+	}
+	if fake.numprocs != 1 || fake.configured() != 1 {
+		t.Errorf("Expected one process, got %d (config %d)", fake.numprocs, fake.configured())
+	}
+
+	// Same size: nothing is restarted
+	fake.calls = nil
+	if result := scale(1); result.Status != types.StatusSuccess {
+		t.Fatalf("Expected success, got %s: %s", result.Status, result.Message)
+	}
+	for _, call := range fake.calls {
+		if call == "supervisor.stopProcessGroup" {
+			t.Errorf("Expected no restart for an unchanged size, got calls %v", fake.calls)
+		}
+	}
+
+	if result := scale(0); result.Status != types.StatusFailed {
+		t.Errorf("Expected scaling a Supervisor program to 0 to fail, got %s", result.Status)
+	}
+}
+
+func TestDiffPIDs(t *testing.T) {
+	started, stopped := diffPIDs([]int{1, 2, 3}, []int{2, 3, 4, 5})
+	if !reflect.DeepEqual(started, []int{4, 5}) || !reflect.DeepEqual(stopped, []int{1}) {
+		t.Errorf("Expected started [4 5] stopped [1], got %v %v", started, stopped)
+	}
+}
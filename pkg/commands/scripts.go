@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// scriptChunkSize bounds how many items a single script call inspects, so a
+// multi-million-item queue never blocks Redis for long
+const scriptChunkSize = 1000
+
+// takeJobScript atomically finds one job in a chunk of a list or sorted set
+// and removes it, pushing it to KEYS[2] when given. Chunks are counted from
+// the tail with negative indexes, so workers popping from the head between
+// two calls don't shift jobs past the scan.
+// A job matches when its raw payload equals ARGV[1] or its decoded uuid, id or
+// jobId field equals ARGV[1] exactly. Returns {1, payload} or {0, scanned}.
+//
+// KEYS[1] = source, KEYS[2] = optional destination list
+// ARGV[1] = job key, ARGV[2] = items already scanned from the tail, ARGV[3] = chunk size,
+// ARGV[4] = '1' if source is a zset
+var takeJobScript = redis.NewScript(`
+local first = -(tonumber(ARGV[2]) + tonumber(ARGV[3]))
+local last = -(tonumber(ARGV[2]) + 1)
+local items
+if ARGV[4] == '1' then
+	items = redis.call('ZRANGE', KEYS[1], first, last)
+else
+	items = redis.call('LRANGE', KEYS[1], first, last)
+end
+
+for i = #items, 1, -1 do
+	local item = items[i]
+	local match = item == ARGV[1]
+	-- IDs appear verbatim in the JSON, so only decode candidates
+	if not match and string.find(item, ARGV[1], 1, true) then
+		local ok, job = pcall(cjson.decode, item)
+		if ok and type(job) == 'table' then
+			for _, field in ipairs({'uuid', 'id', 'jobId'}) do
+				local v = job[field]
+				if (type(v) == 'string' or type(v) == 'number') and tostring(v) == ARGV[1] then
+					match = true
+					break
+				end
+			end
+		end
+	end
+
+	if match then
+		if ARGV[4] == '1' then
+			redis.call('ZREM', KEYS[1], item)
+		else
+			redis.call('LREM', KEYS[1], 1, item)
+		end
+		if #KEYS > 1 then
+			redis.call('RPUSH', KEYS[2], item)
+		end
+		return {1, item}
+	end
+end
+
+return {0, #items}
+`)
+
+// moveToListScript atomically moves exact items from a list or sorted set to
+// the tail of a list, skipping items that are no longer in the source. Like
+// Laravel's own scripts it pushes a token per moved job to the notify list.
+// KEYS[1] = source, KEYS[2] = destination list, KEYS[3] = optional notify list
+// ARGV[1] = 1 if source is a zset, ARGV[2..] = items
+var moveToListScript = redis.NewScript(`
+local moved = 0
+for i = 2, #ARGV do
+	local removed
+	if ARGV[1] == '1' then
+		removed = redis.call('ZREM', KEYS[1], ARGV[i])
+	else
+		removed = redis.call('LREM', KEYS[1], 1, ARGV[i])
+	end
+	if removed > 0 then
+		redis.call('RPUSH', KEYS[2], ARGV[i])
+		if #KEYS > 2 then
+			redis.call('RPUSH', KEYS[3], 1)
+		end
+		moved = moved + 1
+	end
+end
+return moved
+`)
+
+// takeJob removes the job identified by jobKey from source (pushing it to dest
+// when dest is not empty), scanning in bounded chunks from the tail. Jobs
+// pushed meanwhile may be scanned twice but none are skipped. It returns the
+// removed payload, or "" if no job matched.
+func takeJob(ctx context.Context, redisClient *redis.Client, source, dest string, sorted bool, jobKey string) (string, error) {
+	keys := []string{source}
+	if dest != "" {
+		keys = append(keys, dest)
+	}
+
+	for scannedTotal := 0; ; {
+		res, err := takeJobScript.Run(ctx, redisClient, keys, jobKey, scannedTotal, scriptChunkSize, boolFlag(sorted)).Slice()
+		if err != nil {
+			return "", err
+		}
+		if len(res) != 2 {
+			return "", fmt.Errorf("unexpected script reply %v", res)
+		}
+
+		if found, _ := res[0].(int64); found == 1 {
+			payload, _ := res[1].(string)
+			return payload, nil
+		}
+
+		scanned, _ := res[1].(int64)
+		if scanned < scriptChunkSize {
+			return "", nil
+		}
+		scannedTotal += int(scanned)
+	}
+}
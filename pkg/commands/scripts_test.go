@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// popAfterScript pops items from the head of a list after every script
+// call, like workers taking jobs while takeJob scans
+type popAfterScript struct {
+	key   string
+	count int
+}
+
+func (h popAfterScript) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h popAfterScript) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		if name := cmd.Name(); err == nil && (name == "evalsha" || name == "eval") {
+			pop := redis.NewStringSliceCmd(ctx, "lpop", h.key, h.count)
+			_ = next(ctx, pop)
+		}
+		return err
+	}
+}
+
+func (h popAfterScript) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func TestTakeJobWhileWorkersPop(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	jobs := 3 * scriptChunkSize
+	for i := 0; i < jobs; i++ {
+		server.RPush("queues:default", fmt.Sprintf(`{"uuid":"job-%d"}`, i))
+	}
+	client.AddHook(popAfterScript{key: "queues:default", count: scriptChunkSize / 2})
+
+	// Past the first chunk from either end, in the range a head-based
+	// scan would skip once the head moved
+	target := scriptChunkSize + scriptChunkSize/5
+	want := fmt.Sprintf(`{"uuid":"job-%d"}`, target)
+	payload, err := takeJob(context.Background(), client, "queues:default", "queues:moved", false, fmt.Sprintf("job-%d", target))
+	if err != nil {
+		t.Fatalf("takeJob failed: %v", err)
+	}
+	if payload != want {
+		t.Fatalf("Expected %s, got %q", want, payload)
+	}
+	if moved, _ := server.List("queues:moved"); len(moved) != 1 || moved[0] != want {
+		t.Errorf("Expected the job in queues:moved, got %v", moved)
+	}
+}
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/redis/go-redis/v9"
+)
+
+// projectSettings returns the detected settings of the payload's project.
+// Without a project name a host running a single project uses its
+// settings; on other hosts, or with detection off, it returns nil and the
+// agent's defaults apply.
+func projectSettings(projects *laravel.Projects, name string) (*laravel.Settings, error) {
+	if projects == nil {
+		return nil, nil
+	}
+	project, err := projects.Resolve(name)
+	if err != nil {
+		if name != "" {
+			return nil, fmt.Errorf("Laravel project not found: %w", err)
+		}
+		return nil, nil
+	}
+	return project.Settings, nil
+}
+
+// laravelQueue resolves the Redis client and key prefix of the project's
+// queue connection. release closes the client if one was opened for
+// another database.
+func laravelQueue(projects *laravel.Projects, name string, base *redis.Client) (*redis.Client, string, func(), error) {
+	settings, err := projectSettings(projects, name)
+	if err != nil {
+		return base, "", func() {}, err
+	}
+	client, keyPrefix, release := queueFor(settings, base)
+	return client, keyPrefix, release, nil
+}
+
+// queueFor returns the Redis client and key prefix of a queue connection
+// with the given settings (nil = the agent's defaults)
+func queueFor(settings *laravel.Settings, base *redis.Client) (*redis.Client, string, func()) {
+	if settings == nil || settings.QueueDriver != "redis" {
+		return base, "", func() {}
+	}
+	client, release := redisFor(base, settings.Redis.DB)
+	return client, settings.KeyPrefix(), release
+}
+
+// failedJobStore resolves the payload's project and its failed job store,
+// which is nil if the project has none
+func failedJobStore(stores *laravel.FailedStores, projects *laravel.Projects, name string) (laravel.Project, laravel.FailedJobStore, error) {
+	if projects == nil {
+		return laravel.Project{}, nil, nil
+	}
+	project, err := projects.Resolve(name)
+	if err != nil {
+		return laravel.Project{}, nil, fmt.Errorf("Laravel project not found: %w", err)
+	}
+	store, err := stores.For(project)
+	if err != nil {
+		return project, nil, fmt.Errorf("Failed to open the failed job store of %s: %w", project.Name, err)
+	}
+	return project, store, nil
+}
+
+// redisFor returns a client on db and a function releasing it
+func redisFor(base *redis.Client, db int) (*redis.Client, func()) {
+	if base == nil {
+		return nil, func() {}
+	}
+	client := laravel.RedisClient(base, db)
+	if client == base {
+		return base, func() {}
+	}
+	return client, func() { _ = client.Close() }
+}
+
+// redisClients hands out clients on the databases of base, opening each
+// other database once. close releases the opened clients.
+type redisClients struct {
+	base    *redis.Client
+	clients map[int]*redis.Client
+}
+
+func newRedisClients(base *redis.Client) *redisClients {
+	return &redisClients{base: base, clients: make(map[int]*redis.Client)}
+}
+
+// get returns a client on db (nil without a base client)
+func (c *redisClients) get(db int) *redis.Client {
+	if c.base == nil {
+		return nil
+	}
+	if client, ok := c.clients[db]; ok {
+		return client
+	}
+	client := laravel.RedisClient(c.base, db)
+	c.clients[db] = client
+	return client
+}
+
+func (c *redisClients) close() {
+	for _, client := range c.clients {
+		if client != c.base {
+			_ = client.Close()
+		}
+	}
+}
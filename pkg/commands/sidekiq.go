@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/gravito-framework/quasar-go/pkg/sidekiq"
+	"github.com/redis/go-redis/v9"
+)
+
+// sidekiqTarget locates Sidekiq's keys for RETRY_JOB and DELETE_JOB
+type sidekiqTarget struct {
+	client     *redis.Client // nil = the monitor client
+	namespace  sidekiq.Namespace
+	namespaces map[string]sidekiq.Namespace // Queues with their own namespace, by name
+}
+
+func newSidekiqTarget(client *redis.Client, namespace string, queueNamespaces map[string]string) sidekiqTarget {
+	t := sidekiqTarget{client: client, namespace: sidekiq.Namespace(namespace), namespaces: make(map[string]sidekiq.Namespace)}
+	for queue, ns := range queueNamespaces {
+		t.namespaces[queue] = sidekiq.Namespace(ns)
+	}
+	return t
+}
+
+// namespacesFor returns the namespaces a job may be in: its queue's, or
+// without a queue the default one followed by those of other queues
+func (t sidekiqTarget) namespacesFor(queue string) []sidekiq.Namespace {
+	if queue != "" {
+		if ns, ok := t.namespaces[queue]; ok {
+			return []sidekiq.Namespace{ns}
+		}
+		return []sidekiq.Namespace{t.namespace}
+	}
+
+	namespaces := []sidekiq.Namespace{t.namespace}
+	for _, ns := range t.namespaces {
+		if !slices.Contains(namespaces, ns) {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	slices.Sort(namespaces[1:])
+	return namespaces
+}
+
+// sidekiqSets returns the sets a job may be taken from: the requested
+// state, or the retry set followed by the dead set
+func sidekiqSets(state string) ([]string, error) {
+	switch state {
+	case "":
+		return []string{sidekiq.SetRetry, sidekiq.SetDead}, nil
+	case sidekiq.SetRetry, sidekiq.SetDead:
+		return []string{state}, nil
+	}
+	return nil, fmt.Errorf("unsupported Sidekiq state %q (expected retry or dead)", state)
+}
+
+// sidekiqOp is sidekiq.Retry or sidekiq.Delete
+type sidekiqOp func(ctx context.Context, client *redis.Client, ns sidekiq.Namespace, set, jid string) (*sidekiq.Job, error)
+
+// run applies op to the first set holding the job, in the namespaces of
+// queue (see namespacesFor), and returns the job, its namespace and the set
+// it was found in
+func (t sidekiqTarget) run(ctx context.Context, redisClient *redis.Client, queue, state, jid string, op sidekiqOp) (*sidekiq.Job, sidekiq.Namespace, string, error) {
+	client := t.client
+	if client == nil {
+		client = redisClient
+	}
+	if client == nil {
+		return nil, "", "", fmt.Errorf("Sidekiq Redis is not configured")
+	}
+
+	sets, err := sidekiqSets(state)
+	if err != nil {
+		return nil, "", "", err
+	}
+	for _, ns := range t.namespacesFor(queue) {
+		for _, set := range sets {
+			job, err := op(ctx, client, ns, set, jid)
+			if errors.Is(err, sidekiq.ErrJobNotFound) {
+				continue
+			}
+			return job, ns, set, err
+		}
+	}
+	return nil, "", "", sidekiq.ErrJobNotFound
+}
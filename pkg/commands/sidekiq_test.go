@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRetrySidekiqJobInQueueNamespace(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	server.ZAdd("critical:sidekiq:myapp:retry", 1, `{"class":"HardJob","jid":"abc","queue":"critical","retry_count":1}`)
+	server.ZAdd("myapp:retry", 1, `{"class":"HardJob","jid":"def","queue":"default","retry_count":1}`)
+
+	executor := NewRetryJobExecutor(nil, nil).WithSidekiq(nil, "myapp", map[string]string{"critical": "critical:sidekiq:myapp"})
+
+	tests := []struct {
+		name   string
+		queue  string
+		jid    string
+		target string
+		found  bool
+	}{
+		{"queue namespace", "critical", "abc", "critical:sidekiq:myapp:queue:critical", true},
+		{"default namespace", "default", "def", "myapp:queue:default", true},
+		{"wrong queue", "default", "abc", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &types.QuasarCommand{ID: "cmd-1", Payload: types.CommandPayload{Driver: types.DriverSidekiq, Queue: tt.queue, JobKey: tt.jid}}
+			result := executor.Execute(context.Background(), cmd, client)
+			if (result.Status == types.StatusSuccess) != tt.found {
+				t.Fatalf("Expected found=%v, got %s: %s", tt.found, result.Status, result.Message)
+			}
+			if tt.found && !server.Exists(tt.target) {
+				t.Errorf("Expected job in %s", tt.target)
+			}
+		})
+	}
+}
+
+func TestSidekiqTargetNamespaces(t *testing.T) {
+	target := newSidekiqTarget(nil, "myapp", map[string]string{"critical": "critical:myapp", "low": "low:myapp", "bulk": "low:myapp"})
+
+	if got := target.namespacesFor("critical"); len(got) != 1 || got[0] != "critical:myapp" {
+		t.Errorf("Expected the queue's namespace, got %v", got)
+	}
+	if got := target.namespacesFor("default"); len(got) != 1 || got[0] != "myapp" {
+		t.Errorf("Expected the default namespace, got %v", got)
+	}
+	// Without a queue every namespace is searched, the default one first
+	got := target.namespacesFor("")
+	if len(got) != 3 || got[0] != "myapp" || got[1] != "critical:myapp" || got[2] != "low:myapp" {
+		t.Errorf("Expected all namespaces, got %v", got)
+	}
+}
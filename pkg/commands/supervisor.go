@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gravito-framework/quasar-go/pkg/supervisor"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+	"github.com/redis/go-redis/v9"
+)
+
+// Ensure SupervisorActionExecutor implements Executor
+var _ Executor = (*SupervisorActionExecutor)(nil)
+
+// SupervisorActionExecutor handles SUPERVISOR_ACTION commands.
+// Unlike the signal-based Laravel restart, every action is confirmed by
+// re-reading process state from supervisord.
+type SupervisorActionExecutor struct {
+	BaseExecutor
+	client *supervisor.Client
+}
+
+// NewSupervisorActionExecutor creates a new Supervisor executor
+func NewSupervisorActionExecutor(client *supervisor.Client) *SupervisorActionExecutor {
+	return &SupervisorActionExecutor{client: client}
+}
+
+// SupportedType returns SUPERVISOR_ACTION
+func (e *SupervisorActionExecutor) SupportedType() types.CommandType {
+	return types.CmdSupervisorAction
+}
+
+// Execute performs a Supervisor action on a program group or process
+func (e *SupervisorActionExecutor) Execute(ctx context.Context, cmd *types.QuasarCommand, _ *redis.Client) types.CommandResult {
+	action := cmd.Payload.Action
+	program := cmd.Payload.Program
+
+	if action == "" {
+		return e.Failed(cmd.ID, "Missing action in payload")
+	}
+	if program == "" && action != "status" {
+		return e.Failed(cmd.ID, "Missing program in payload")
+	}
+
+	switch action {
+	case "status":
+		return e.status(ctx, cmd.ID)
+	case "restart-group":
+		return e.restartGroup(ctx, cmd.ID, program)
+	case "start-group":
+		return e.confirm(ctx, cmd.ID, program, "RUNNING", e.client.StartProcessGroup(ctx, program))
+	case "stop-group":
+		return e.confirm(ctx, cmd.ID, program, "STOPPED", e.client.StopProcessGroup(ctx, program))
+	case "start":
+		return e.confirm(ctx, cmd.ID, program, "RUNNING", ignoreFault(e.client.StartProcess(ctx, program), supervisor.FaultAlreadyStarted))
+	case "stop":
+		return e.confirm(ctx, cmd.ID, program, "STOPPED", ignoreFault(e.client.StopProcess(ctx, program), supervisor.FaultNotRunning))
+	default:
+		return e.Failed(cmd.ID, fmt.Sprintf("Unknown Supervisor action: %s", action))
+	}
+}
+
+func (e *SupervisorActionExecutor) status(ctx context.Context, cmdID string) types.CommandResult {
+	status, err := e.client.GetStatus(ctx)
+	if err != nil {
+		return e.Failed(cmdID, fmt.Sprintf("Failed to read Supervisor status: %v", err))
+	}
+
+	parts := make([]string, 0, len(status.Groups))
+	for _, g := range status.Groups {
+		parts = append(parts, fmt.Sprintf("%s %d/%d running", g.Name, g.Running, g.Total))
+	}
+	return e.Success(cmdID, strings.Join(parts, "; "))
+}
+
+func (e *SupervisorActionExecutor) restartGroup(ctx context.Context, cmdID, group string) types.CommandResult {
+	result, err := e.client.RestartGroup(ctx, group)
+	if err != nil {
+		return e.Failed(cmdID, fmt.Sprintf("Failed to restart %s: %v", group, err))
+	}
+
+	msg := fmt.Sprintf("Restarted %s: PIDs %v -> %v", group, pids(result.Before), pids(result.After))
+	if !result.Confirmed {
+		return e.Failed(cmdID, msg+" (not all processes came back RUNNING)")
+	}
+	return e.Success(cmdID, msg)
+}
+
+// confirm re-reads process state after an action and checks the expected state.
+// program may be a group name or a "group:name" process name.
+func (e *SupervisorActionExecutor) confirm(ctx context.Context, cmdID, program, expected string, actionErr error) types.CommandResult {
+	if actionErr != nil {
+		return e.Failed(cmdID, fmt.Sprintf("Supervisor action on %s failed: %v", program, actionErr))
+	}
+
+	infos, err := e.client.GetAllProcessInfo(ctx)
+	if err != nil {
+		return e.Failed(cmdID, fmt.Sprintf("Action sent but state could not be confirmed: %v", err))
+	}
+
+	matched, inState := 0, 0
+	for _, info := range infos {
+		if info.Group != program && info.FullName() != program {
+			continue
+		}
+		matched++
+		// A stopped process may also report EXITED
+		if info.State == expected || (expected == "STOPPED" && info.State == "EXITED") {
+			inState++
+		}
+	}
+
+	if matched == 0 {
+		return e.Failed(cmdID, fmt.Sprintf("No Supervisor process matches %s", program))
+	}
+	if inState != matched {
+		return e.Failed(cmdID, fmt.Sprintf("%d/%d processes of %s are %s", inState, matched, program, expected))
+	}
+	return e.Success(cmdID, fmt.Sprintf("%d processes of %s confirmed %s", matched, program, expected))
+}
+
+// ignoreFault treats a specific Supervisor fault (e.g. already started) as success
+func ignoreFault(err error, code int) error {
+	var fault *supervisor.Fault
+	if errors.As(err, &fault) && fault.Code == code {
+		return nil
+	}
+	return err
+}
+
+func pids(infos []supervisor.ProcessInfo) []int {
+	result := make([]int, 0, len(infos))
+	for _, info := range infos {
+		result = append(result, info.PID)
+	}
+	return result
+}
@@ -0,0 +1,18 @@
+//go:build windows
+
+package commands
+
+import (
+	"os"
+	"syscall"
+)
+
+// newSysProcAttr uses default process attributes on Windows
+func newSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{}
+}
+
+// killProcessGroup kills the process; Windows has no process groups to signal
+func killProcessGroup(p *os.Process) error {
+	return p.Kill()
+}
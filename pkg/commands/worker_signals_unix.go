@@ -0,0 +1,11 @@
+//go:build !windows
+
+package commands
+
+import "syscall"
+
+// workerSignals maps pause/resume to the signals queue:work and horizon handle
+var workerSignals = map[string]syscall.Signal{
+	"pause":  syscall.SIGUSR2,
+	"resume": syscall.SIGCONT,
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package commands
+
+import "syscall"
+
+// workerSignals is empty on Windows: workers cannot be paused by signal
+var workerSignals = map[string]syscall.Signal{}
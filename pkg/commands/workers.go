@@ -0,0 +1,358 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/gravito-framework/quasar-go/pkg/probes"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+	"github.com/redis/go-redis/v9"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// Ensure WorkerActionExecutor implements Executor
+var _ Executor = (*WorkerActionExecutor)(nil)
+
+// Worker states reported in WORKER_ACTION results
+const (
+	workerRunning    = "running"
+	workerPaused     = "paused"
+	workerSignaled   = "signaled" // Alive after pause/resume; Laravel doesn't expose the result
+	workerStopped    = "stopped"  // SIGSTOPped, e.g. by a debugger or job control
+	workerTerminated = "terminated"
+	workerKilled     = "killed"
+	workerGone       = "gone"
+)
+
+// workerPollInterval is how often process state is re-read while waiting
+const workerPollInterval = 250 * time.Millisecond
+
+// killConfirmTimeout bounds the wait for a process to disappear after SIGKILL
+const killConfirmTimeout = 5 * time.Second
+
+// WorkerActionExecutor handles WORKER_ACTION commands: pause, resume or
+// terminate specific Laravel worker PIDs, or all workers of a queue.
+// With a project in the payload only that project's workers are selected.
+type WorkerActionExecutor struct {
+	BaseExecutor
+	gracePeriod time.Duration // Time between SIGTERM and SIGKILL
+	projects    *laravel.Projects
+}
+
+// NewWorkerActionExecutor creates a new worker executor
+func NewWorkerActionExecutor(gracePeriod time.Duration, projects *laravel.Projects) *WorkerActionExecutor {
+	return &WorkerActionExecutor{gracePeriod: gracePeriod, projects: projects}
+}
+
+// SupportedType returns WORKER_ACTION
+func (e *WorkerActionExecutor) SupportedType() types.CommandType {
+	return types.CmdWorkerAction
+}
+
+// WorkerStateChange reports one worker's state before and after the action
+type WorkerStateChange struct {
+	PID       int32  `json:"pid"`
+	Before    string `json:"before"`
+	After     string `json:"after"`
+	Changed   bool   `json:"changed"`
+	Confirmed bool   `json:"confirmed"` // After was observed, not just requested
+	Error     string `json:"error,omitempty"`
+}
+
+// WorkerActionResult lists the workers an action touched
+type WorkerActionResult struct {
+	Action  string              `json:"action"`
+	Workers []WorkerStateChange `json:"workers"`
+	Missing []int32             `json:"missing,omitempty"` // Requested PIDs that are not Laravel workers
+}
+
+// workerTarget is a Laravel worker process selected for an action
+type workerTarget struct {
+	pid        int32
+	createTime int64 // Detects PID reuse while we wait
+	root       string
+	queues     []string
+}
+
+// Execute signals the selected workers and confirms their new state
+func (e *WorkerActionExecutor) Execute(ctx context.Context, cmd *types.QuasarCommand, _ *redis.Client) types.CommandResult {
+	action := cmd.Payload.Action
+	switch action {
+	case "pause", "resume":
+		if _, ok := workerSignals[action]; !ok {
+			return e.Failed(cmd.ID, fmt.Sprintf("Worker %s is not supported on this platform", action))
+		}
+	case "terminate":
+	case "":
+		return e.Failed(cmd.ID, "Missing action in payload")
+	default:
+		return e.Failed(cmd.ID, fmt.Sprintf("Unknown worker action: %s", action))
+	}
+	if len(cmd.Payload.PIDs) == 0 && cmd.Payload.Queue == "" {
+		return e.Failed(cmd.ID, "Missing pids or queue in payload")
+	}
+
+	workers, err := listWorkers(ctx)
+	if err != nil {
+		return e.Failed(cmd.ID, fmt.Sprintf("Failed to list processes: %v", err))
+	}
+	root := ""
+	if cmd.Payload.Project != "" {
+		project, err := e.projects.Resolve(cmd.Payload.Project)
+		if err != nil {
+			return e.Failed(cmd.ID, fmt.Sprintf("Laravel project not found: %v", err))
+		}
+		root = project.Root
+	}
+	targets, missing := selectWorkers(workers, cmd.Payload.PIDs, cmd.Payload.Queue, root)
+	if len(targets) == 0 {
+		return e.Failed(cmd.ID, "No matching Laravel workers found")
+	}
+
+	result := WorkerActionResult{Action: action, Missing: missing}
+	if action == "terminate" {
+		result.Workers = e.terminate(ctx, targets)
+	} else {
+		result.Workers = e.signal(ctx, targets, action)
+	}
+
+	expected := workerActionState(action)
+	done, changed := 0, 0
+	for _, w := range result.Workers {
+		if w.After == expected || (action == "terminate" && w.After == workerKilled) {
+			done++
+		}
+		if w.Changed {
+			changed++
+		}
+	}
+
+	message := fmt.Sprintf("%d/%d workers %s (%d changed state)", done, len(result.Workers), expected, changed)
+	if expected == workerSignaled {
+		message = fmt.Sprintf("%d/%d workers signaled to %s (unconfirmed: workers don't report pause state)", done, len(result.Workers), action)
+	}
+	if len(missing) > 0 {
+		message += fmt.Sprintf(", %d PIDs not found", len(missing))
+	}
+	if done != len(result.Workers) || len(missing) > 0 {
+		return e.FailedWithData(cmd.ID, message, result)
+	}
+	return e.SuccessWithData(cmd.ID, message, result)
+}
+
+// signal pauses or resumes workers. queue:work keeps running while paused and
+// doesn't expose whether it is, so the result is only that the signal was
+// delivered and the process is still alive and not stopped.
+func (e *WorkerActionExecutor) signal(ctx context.Context, targets []workerTarget, action string) []WorkerStateChange {
+	sig := workerSignals[action]
+	pause := action == "pause"
+
+	changes := make([]WorkerStateChange, len(targets))
+	for i, t := range targets {
+		changes[i] = WorkerStateChange{PID: t.pid, Before: workerState(ctx, t)}
+		if err := sendSignal(ctx, t.pid, sig); err != nil {
+			changes[i].Error = err.Error()
+		}
+	}
+
+	// Give workers a moment to handle the signal before re-reading
+	sleepCtx(ctx, workerPollInterval)
+
+	for i, t := range targets {
+		state := workerState(ctx, t)
+		switch {
+		case state == workerGone:
+			probes.SetWorkerPaused(t.pid, t.createTime, false)
+			changes[i].Confirmed = true
+		case changes[i].Error == "" && (state == workerRunning || state == workerPaused):
+			// Remembered so heartbeats show what was requested
+			probes.SetWorkerPaused(t.pid, t.createTime, pause)
+			state = workerSignaled
+		default:
+			changes[i].Confirmed = true
+		}
+		changes[i].After = state
+		changes[i].Changed = changes[i].Confirmed && state != changes[i].Before
+	}
+	return changes
+}
+
+// terminate sends SIGTERM so workers finish their current job, then SIGKILLs
+// any that are still alive after the grace period
+func (e *WorkerActionExecutor) terminate(ctx context.Context, targets []workerTarget) []WorkerStateChange {
+	changes := make([]WorkerStateChange, len(targets))
+	for i, t := range targets {
+		changes[i] = WorkerStateChange{PID: t.pid, Before: workerState(ctx, t)}
+		// The exit is requested, so it must not count as a crash
+		probes.ExpectWorkerExit(t.pid)
+		if err := sendSignal(ctx, t.pid, syscall.SIGTERM); err != nil {
+			changes[i].Error = err.Error()
+		}
+		// A stopped process only handles SIGTERM once continued
+		if sig, ok := workerSignals["resume"]; ok && changes[i].Before == workerStopped {
+			_ = sendSignal(ctx, t.pid, sig)
+		}
+	}
+
+	alive := waitForExit(ctx, targets, e.gracePeriod)
+	for i, t := range targets {
+		if !alive[i] {
+			changes[i].After = workerTerminated
+			continue
+		}
+		probes.ExpectWorkerExit(t.pid)
+		if err := sendSignal(ctx, t.pid, syscall.SIGKILL); err != nil {
+			changes[i].Error = err.Error()
+		}
+	}
+
+	stillAlive := waitForExit(ctx, targets, killConfirmTimeout)
+	for i, t := range targets {
+		switch {
+		case !alive[i]:
+			// Exited within the grace period
+		case stillAlive[i]:
+			changes[i].After = workerState(ctx, t)
+		default:
+			changes[i].After = workerKilled
+		}
+		changes[i].Changed = changes[i].After != changes[i].Before
+		changes[i].Confirmed = true
+		if changes[i].After == workerTerminated || changes[i].After == workerKilled {
+			changes[i].Error = ""
+			probes.SetWorkerPaused(t.pid, t.createTime, false)
+		}
+	}
+	return changes
+}
+
+// waitForExit polls until every target has exited or the timeout passes.
+// It returns which targets are still alive.
+func waitForExit(ctx context.Context, targets []workerTarget, timeout time.Duration) []bool {
+	alive := make([]bool, len(targets))
+	deadline := time.Now().Add(timeout)
+	for {
+		remaining := 0
+		for i, t := range targets {
+			alive[i] = workerState(ctx, t) != workerGone
+			if alive[i] {
+				remaining++
+			}
+		}
+		if remaining == 0 || !time.Now().Before(deadline) || ctx.Err() != nil {
+			return alive
+		}
+		sleepCtx(ctx, workerPollInterval)
+	}
+}
+
+// workerState re-reads a worker's process status. Exited, zombie and
+// reused PIDs all count as gone.
+func workerState(ctx context.Context, t workerTarget) string {
+	p, err := process.NewProcessWithContext(ctx, t.pid)
+	if err != nil {
+		return workerGone
+	}
+	if createTime, err := p.CreateTimeWithContext(ctx); err != nil || createTime != t.createTime {
+		return workerGone
+	}
+
+	status, err := p.StatusWithContext(ctx)
+	if err != nil {
+		return workerGone
+	}
+	switch {
+	case slices.Contains(status, process.Zombie):
+		return workerGone
+	case slices.Contains(status, process.Stop):
+		return workerStopped
+	}
+
+	if probes.IsWorkerPaused(t.pid, t.createTime) {
+		return workerPaused
+	}
+	return workerRunning
+}
+
+// listWorkers scans for Laravel worker processes
+func listWorkers(ctx context.Context) ([]workerTarget, error) {
+	procs, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var workers []workerTarget
+	for _, p := range procs {
+		cmdline, err := p.CmdlineWithContext(ctx)
+		if err != nil || !probes.IsLaravelWorkerCmdline(cmdline) {
+			continue
+		}
+		createTime, _ := p.CreateTimeWithContext(ctx)
+		cwd, _ := p.CwdWithContext(ctx)
+		workers = append(workers, workerTarget{
+			pid:        p.Pid,
+			createTime: createTime,
+			root:       cwd,
+			queues:     probes.ParseWorkerQueues(cmdline),
+		})
+	}
+	return workers, nil
+}
+
+// selectWorkers picks workers by PID or by queue, within a project root if
+// one is given. Only Laravel workers can be selected, so arbitrary PIDs
+// cannot be signalled.
+func selectWorkers(workers []workerTarget, pids []int32, queue, root string) ([]workerTarget, []int32) {
+	var selected []workerTarget
+	found := make(map[int32]bool)
+	for _, w := range workers {
+		if root != "" && !laravel.SameRoot(w.root, root) {
+			continue
+		}
+		if slices.Contains(pids, w.pid) || (queue != "" && slices.Contains(w.queues, queue)) {
+			selected = append(selected, w)
+			found[w.pid] = true
+		}
+	}
+
+	var missing []int32
+	for _, pid := range pids {
+		if !found[pid] {
+			missing = append(missing, pid)
+		}
+	}
+	return selected, missing
+}
+
+func sendSignal(ctx context.Context, pid int32, sig syscall.Signal) error {
+	p, err := process.NewProcessWithContext(ctx, pid)
+	if err != nil {
+		return err
+	}
+	if err := p.SendSignalWithContext(ctx, sig); err != nil {
+		return fmt.Errorf("%s: %w", strings.ToUpper(sig.String()), err)
+	}
+	return nil
+}
+
+// workerActionState is the state an action should leave a worker in
+func workerActionState(action string) string {
+	switch action {
+	case "pause", "resume":
+		return workerSignaled
+	default:
+		return workerTerminated
+	}
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/gravito-framework/quasar-go/pkg/probes"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+)
+
+func TestSelectWorkers(t *testing.T) {
+	workers := []workerTarget{
+		{pid: 100, root: "/var/www/shop", queues: []string{"default"}},
+		{pid: 101, root: "/var/www/shop", queues: []string{"emails", "default"}},
+		{pid: 102, root: "/var/www/shop", queues: []string{"reports"}},
+		{pid: 200, root: "/var/www/blog", queues: []string{"default"}},
+	}
+
+	tests := []struct {
+		name        string
+		pids        []int32
+		queue       string
+		root        string
+		wantPIDs    []int32
+		wantMissing []int32
+	}{
+		{"by pid", []int32{102}, "", "", []int32{102}, nil},
+		{"by queue", nil, "default", "", []int32{100, 101, 200}, nil},
+		{"by queue in project", nil, "default", "/var/www/shop", []int32{100, 101}, nil},
+		{"pid of other project", []int32{200}, "", "/var/www/shop", nil, []int32{200}},
+		{"pid and queue", []int32{102}, "emails", "", []int32{101, 102}, nil},
+		{"non-worker pid", []int32{100, 1}, "", "", []int32{100}, []int32{1}},
+		{"unknown queue", nil, "imports", "", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, missing := selectWorkers(workers, tt.pids, tt.queue, tt.root)
+
+			var pids []int32
+			for _, w := range selected {
+				pids = append(pids, w.pid)
+			}
+			if !reflect.DeepEqual(pids, tt.wantPIDs) {
+				t.Errorf("Expected PIDs %v, got %v", tt.wantPIDs, pids)
+			}
+			if !reflect.DeepEqual(missing, tt.wantMissing) {
+				t.Errorf("Expected missing %v, got %v", tt.wantMissing, missing)
+			}
+		})
+	}
+}
+
+func TestWorkerTerminateIsNotACrashLoop(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+
+	const workers = 5
+	probes.ConfigureCrashLoopDetection(workers, time.Minute)
+	defer probes.ConfigureCrashLoopDetection(5, 5*time.Minute)
+
+	root := t.TempDir()
+	startWorkerPool(t, root, workers)
+	probes.GetLaravelWorkerStats()
+	probes.DrainWorkerEvents()
+
+	projects := laravel.NewProjects([]laravel.Project{{Name: "app", Root: root}}, nil)
+	executor := NewWorkerActionExecutor(time.Second, projects)
+	cmd := &types.QuasarCommand{ID: "cmd-1", Payload: types.CommandPayload{Action: "terminate", Queue: "default", Project: "app"}}
+	if result := executor.Execute(context.Background(), cmd, nil); result.Status != types.StatusSuccess {
+		t.Fatalf("Expected workers terminated, got %s: %s", result.Status, result.Message)
+	}
+
+	assertExpectedExits(t, root, workers)
+}
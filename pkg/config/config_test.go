@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -17,6 +18,13 @@ func TestLoad(t *testing.T) {
 		"QUASAR_MONITOR_REDIS_URL",
 		"QUASAR_INTERVAL",
 		"QUASAR_QUEUES",
+		"QUASAR_DISK_INCLUDE",
+		"QUASAR_DISK_EXCLUDE",
+		"QUASAR_CPU_SAMPLE_INTERVAL",
+		"QUASAR_CPU_PER_CORE",
+		"QUASAR_NOTIFY_SLACK_URL",
+		"QUASAR_NOTIFY_EVENTS",
+		"QUASAR_NOTIFY_RATE_LIMIT",
 	}
 
 	for _, key := range envVars {
@@ -45,6 +53,10 @@ func TestLoad(t *testing.T) {
 		if cfg.Interval != 10*time.Second {
 			t.Errorf("Expected default interval 10s, got %v", cfg.Interval)
 		}
+
+		if cfg.CPUSampleInterval != time.Second {
+			t.Errorf("Expected default CPU sample interval 1s, got %v", cfg.CPUSampleInterval)
+		}
 	})
 
 	t.Run("from environment", func(t *testing.T) {
@@ -89,6 +101,102 @@ func TestLoad(t *testing.T) {
 			t.Errorf("Expected queue[1] emails:redis, got %s:%s", cfg.Queues[1].Name, cfg.Queues[1].Type)
 		}
 	})
+
+	t.Run("cpu sampling", func(t *testing.T) {
+		os.Setenv("QUASAR_CPU_SAMPLE_INTERVAL", "500ms")
+		os.Setenv("QUASAR_CPU_PER_CORE", "true")
+
+		cfg := Load()
+
+		if cfg.CPUSampleInterval != 500*time.Millisecond {
+			t.Errorf("Expected CPU sample interval 500ms, got %v", cfg.CPUSampleInterval)
+		}
+
+		if !cfg.CPUPerCore {
+			t.Error("Expected per-core CPU to be enabled")
+		}
+	})
+
+	t.Run("process groups", func(t *testing.T) {
+		os.Setenv("QUASAR_PROCESS_GROUPS", "php-fpm, nginx")
+		os.Setenv("QUASAR_PROCESS_GROUP_PHP_FPM_EXE", "php-fpm*")
+		os.Setenv("QUASAR_PROCESS_GROUP_PHP_FPM_USER", "www-data")
+		os.Setenv("QUASAR_PROCESS_GROUP_NGINX_CMDLINE", "^nginx: worker")
+		defer func() {
+			for _, key := range []string{
+				"QUASAR_PROCESS_GROUPS",
+				"QUASAR_PROCESS_GROUP_PHP_FPM_EXE",
+				"QUASAR_PROCESS_GROUP_PHP_FPM_USER",
+				"QUASAR_PROCESS_GROUP_NGINX_CMDLINE",
+			} {
+				os.Unsetenv(key)
+			}
+		}()
+
+		cfg := Load()
+
+		if len(cfg.ProcessGroups) != 2 {
+			t.Fatalf("Expected 2 process groups, got %d", len(cfg.ProcessGroups))
+		}
+
+		expected := ProcessGroupConfig{Name: "php-fpm", Exe: "php-fpm*", User: "www-data"}
+		if cfg.ProcessGroups[0] != expected {
+			t.Errorf("Expected %+v, got %+v", expected, cfg.ProcessGroups[0])
+		}
+
+		if cfg.ProcessGroups[1].Cmdline != "^nginx: worker" {
+			t.Errorf("Expected nginx cmdline pattern, got %q", cfg.ProcessGroups[1].Cmdline)
+		}
+	})
+
+	t.Run("disk filters", func(t *testing.T) {
+		os.Setenv("QUASAR_DISK_INCLUDE", "/, /var/www/*")
+		os.Setenv("QUASAR_DISK_EXCLUDE", "/snap/*")
+
+		cfg := Load()
+
+		if len(cfg.DiskInclude) != 2 || cfg.DiskInclude[1] != "/var/www/*" {
+			t.Errorf("Expected 2 include patterns, got %v", cfg.DiskInclude)
+		}
+
+		if len(cfg.DiskExclude) != 1 || cfg.DiskExclude[0] != "/snap/*" {
+			t.Errorf("Expected exclude pattern /snap/*, got %v", cfg.DiskExclude)
+		}
+	})
+
+	t.Run("laravel overrides", func(t *testing.T) {
+		os.Setenv("QUASAR_LARAVEL_CACHE_PREFIX", "")
+		os.Setenv("QUASAR_HORIZON_PREFIX", "shop_horizon:")
+		defer os.Unsetenv("QUASAR_LARAVEL_CACHE_PREFIX")
+		defer os.Unsetenv("QUASAR_HORIZON_PREFIX")
+
+		cfg := Load()
+
+		expected := LaravelOverrides{HorizonPrefix: true, CachePrefix: true}
+		if cfg.LaravelOverrides != expected || cfg.LaravelCachePrefix != "" || cfg.LaravelConnection != "redis" {
+			t.Errorf("Expected explicit cache and Horizon prefixes, got %+v (%q, %q)", cfg.LaravelOverrides, cfg.LaravelCachePrefix, cfg.LaravelConnection)
+		}
+	})
+
+	t.Run("notifications", func(t *testing.T) {
+		os.Setenv("QUASAR_NOTIFY_SLACK_URL", "https://hooks.slack.com/services/T/B/X")
+		os.Setenv("QUASAR_NOTIFY_EVENTS", "alert.*, agent.error")
+		os.Setenv("QUASAR_NOTIFY_RATE_LIMIT", "0")
+
+		cfg := Load()
+
+		if cfg.Notify.SlackURL == "" {
+			t.Error("Expected Slack URL to be set")
+		}
+
+		if len(cfg.Notify.Events) != 2 || cfg.Notify.Events[1] != "agent.error" {
+			t.Errorf("Expected 2 event patterns, got %v", cfg.Notify.Events)
+		}
+
+		if cfg.Notify.RateLimit != 0 || cfg.Notify.Retries != 3 {
+			t.Errorf("Expected rate limit 0 and 3 retries, got %d and %d", cfg.Notify.RateLimit, cfg.Notify.Retries)
+		}
+	})
 }
 
 func TestValidate(t *testing.T) {
@@ -181,3 +289,111 @@ func TestParseQueues(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePSIThresholds(t *testing.T) {
+	result := parsePSIThresholds("memory.full.avg60=5, cgroup.cpu.some.avg10=50, bogus=1, io.some.avg10=abc")
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 thresholds, got %d", len(result))
+	}
+
+	expected := PSIThreshold{Scope: "system", Resource: "memory", Kind: "full", Window: "avg60", Limit: 5}
+	if result[0] != expected {
+		t.Errorf("Expected %+v, got %+v", expected, result[0])
+	}
+
+	expected = PSIThreshold{Scope: "cgroup", Resource: "cpu", Kind: "some", Window: "avg10", Limit: 50}
+	if result[1] != expected {
+		t.Errorf("Expected %+v, got %+v", expected, result[1])
+	}
+}
+
+func TestParseFPMPools(t *testing.T) {
+	result := parseFPMPools("www=unix:///run/php/php-fpm.sock, api = tcp://127.0.0.1:9001, http://localhost/status", "/fpm-status")
+
+	expected := []FPMPoolConfig{
+		{Name: "www", Address: "unix:///run/php/php-fpm.sock", StatusPath: "/fpm-status"},
+		{Name: "api", Address: "tcp://127.0.0.1:9001", StatusPath: "/fpm-status"},
+		{Name: "www", Address: "http://localhost/status", StatusPath: "/fpm-status"},
+	}
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d pools, got %d", len(expected), len(result))
+	}
+
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("Pool[%d]: expected %+v, got %+v", i, expected[i], result[i])
+		}
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected uint64
+		ok       bool
+	}{
+		{"512", 512, true},
+		{"256MB", 256 << 20, true},
+		{"256m", 256 << 20, true},
+		{"1.5G", 3 << 29, true},
+		{"64 KB", 64 << 10, true},
+		{"lots", 0, false},
+	}
+
+	for _, tt := range tests {
+		result, ok := parseBytes(tt.input)
+		if ok != tt.ok || result != tt.expected {
+			t.Errorf("parseBytes(%q): expected %d (ok=%v), got %d (ok=%v)", tt.input, tt.expected, tt.ok, result, ok)
+		}
+	}
+}
+
+func TestParseLaravelProjects(t *testing.T) {
+	result := parseLaravelProjects("shop=/var/www/shop, blog = /srv/blog ,broken,=/no/name")
+
+	expected := []LaravelProjectConfig{
+		{Name: "shop", Root: "/var/www/shop", PHP: "php"},
+		{Name: "blog", Root: "/srv/blog", PHP: "php"},
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, result)
+	}
+}
+
+func TestParseArtisanRules(t *testing.T) {
+	result := parseArtisanRules("cache:clear; queue:retry [0-9a-f-]+|all ;bad:cmd ([; app:prune --days=[0-9]+ --force")
+
+	expected := []ArtisanRule{
+		{Command: "cache:clear"},
+		{Command: "queue:retry", Args: []string{"[0-9a-f-]+|all"}},
+		{Command: "app:prune", Args: []string{"--days=[0-9]+", "--force"}},
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, result)
+	}
+}
+
+func TestParseAlertRules(t *testing.T) {
+	result := parseAlertRules("backlog=queue.default.waiting > 1000 for 5m critical; cpu_hot=cpu.system >= 90;" +
+		"bad_op=cpu.system ~ 1; bad_for=cpu.system > 1 for; no_workers = workers.count == 0 for 30")
+
+	expected := []AlertRule{
+		{Name: "backlog", Metric: "queue.default.waiting", Op: ">", Threshold: 1000, For: 5 * time.Minute, Severity: "critical"},
+		{Name: "cpu_hot", Metric: "cpu.system", Op: ">=", Threshold: 90, Severity: "warning"},
+		{Name: "no_workers", Metric: "workers.count", Op: "==", Threshold: 0, For: 30 * time.Second, Severity: "warning"},
+	}
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d rules, got %d: %+v", len(expected), len(result), result)
+	}
+
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("Rule[%d]: expected %+v, got %+v", i, expected[i], result[i])
+		}
+	}
+}
@@ -0,0 +1,197 @@
+// Package guard provides local policies that act on worker processes.
+package guard
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/probes"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// EventWorkerRecycled is emitted when the guard gracefully terminates a worker
+const EventWorkerRecycled = "worker.recycled"
+
+// MemoryPolicy configures the memory-leak guard
+type MemoryPolicy struct {
+	MaxRSS          uint64        // Hard RSS limit in bytes (0 = disabled)
+	MaxGrowthPerMin uint64        // RSS growth trend limit in bytes/minute (0 = disabled)
+	TrendWindow     time.Duration // Samples used for the growth trend (default: 10m)
+	Cooldown        time.Duration // Minimum time between two recycles (default: 1m)
+	MaxConcurrent   int           // Max recycles in flight at once (default: 1)
+}
+
+// rssSample is one RSS measurement of a worker
+type rssSample struct {
+	at  time.Time
+	rss uint64
+}
+
+// MemoryGuard watches worker RSS and recycles leaking workers with SIGTERM.
+// Supervisor (or the built-in manager) is expected to start a replacement.
+type MemoryGuard struct {
+	policy MemoryPolicy
+
+	mu          sync.Mutex
+	samples     map[int32][]rssSample
+	inFlight    map[int32]time.Time // PIDs signalled but not yet exited
+	lastRecycle time.Time
+	terminate   func(pid int32) error
+	inFlightTTL time.Duration // Forget in-flight PIDs that never exit
+}
+
+// NewMemoryGuard creates a guard with the given policy
+func NewMemoryGuard(policy MemoryPolicy) *MemoryGuard {
+	if policy.TrendWindow <= 0 {
+		policy.TrendWindow = 10 * time.Minute
+	}
+	if policy.Cooldown <= 0 {
+		policy.Cooldown = time.Minute
+	}
+	if policy.MaxConcurrent <= 0 {
+		policy.MaxConcurrent = 1
+	}
+
+	return &MemoryGuard{
+		policy:      policy,
+		samples:     make(map[int32][]rssSample),
+		inFlight:    make(map[int32]time.Time),
+		terminate:   terminateProcess,
+		inFlightTTL: 5 * time.Minute,
+	}
+}
+
+// Evaluate records the latest worker RSS values and recycles workers that
+// cross the hard limit or leak faster than the growth limit. Only queue:work
+// and horizon:work processes are recycled. It returns one event per recycled worker.
+func (g *MemoryGuard) Evaluate(workers []probes.LaravelWorkerDetail, now time.Time) []types.Event {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	alive := make(map[int32]bool, len(workers))
+	for _, w := range workers {
+		alive[w.PID] = true
+	}
+
+	// Forget exited workers and stale in-flight recycles
+	for pid := range g.samples {
+		if !alive[pid] {
+			delete(g.samples, pid)
+		}
+	}
+	for pid, at := range g.inFlight {
+		if !alive[pid] || now.Sub(at) > g.inFlightTTL {
+			delete(g.inFlight, pid)
+		}
+	}
+
+	var events []types.Event
+	for _, w := range workers {
+		// Terminating the Horizon master or a supervisor would stop a whole pool
+		if w.Memory == 0 || !probes.IsJobWorkerCmdline(w.Cmdline) {
+			continue
+		}
+		g.record(w.PID, w.Memory, now)
+
+		if _, ok := g.inFlight[w.PID]; ok {
+			continue
+		}
+
+		reason := g.violation(w.PID, w.Memory)
+		if reason == "" {
+			continue
+		}
+
+		// Never recycle the whole pool at once
+		if len(g.inFlight) >= g.policy.MaxConcurrent || now.Sub(g.lastRecycle) < g.policy.Cooldown {
+			continue
+		}
+
+		// The lifecycle tracker reports the exit as expected, not as a crash
+		probes.ExpectWorkerExit(w.PID)
+		if err := g.terminate(w.PID); err != nil {
+			events = append(events, types.NewEvent(EventWorkerRecycled, types.SeverityWarning,
+				fmt.Sprintf("Failed to recycle worker %d: %v", w.PID, err),
+				map[string]interface{}{"pid": w.PID, "rss": w.Memory, "reason": reason, "error": err.Error()}))
+			continue
+		}
+
+		g.inFlight[w.PID] = now
+		g.lastRecycle = now
+		events = append(events, types.NewEvent(EventWorkerRecycled, types.SeverityWarning,
+			fmt.Sprintf("Recycled worker %d (%s)", w.PID, reason),
+			map[string]interface{}{
+				"pid":      w.PID,
+				"rss":      w.Memory,
+				"reason":   reason,
+				"root":     w.Root,
+				"cmdline":  w.Cmdline,
+				"expected": true,
+			}))
+	}
+
+	return events
+}
+
+// record appends a sample and drops samples older than the trend window
+func (g *MemoryGuard) record(pid int32, rss uint64, now time.Time) {
+	cutoff := now.Add(-g.policy.TrendWindow)
+	samples := append(g.samples[pid], rssSample{at: now, rss: rss})
+	for len(samples) > 0 && samples[0].at.Before(cutoff) {
+		samples = samples[1:]
+	}
+	g.samples[pid] = samples
+}
+
+// violation returns why a worker should be recycled, or "" if it is healthy
+func (g *MemoryGuard) violation(pid int32, rss uint64) string {
+	if g.policy.MaxRSS > 0 && rss >= g.policy.MaxRSS {
+		return fmt.Sprintf("rss %d bytes >= limit %d", rss, g.policy.MaxRSS)
+	}
+
+	if g.policy.MaxGrowthPerMin > 0 {
+		samples := g.samples[pid]
+		// Require at least half a window of history before trusting the trend
+		if len(samples) >= 3 && samples[len(samples)-1].at.Sub(samples[0].at) >= g.policy.TrendWindow/2 {
+			perMin := slope(samples) * 60
+			if perMin >= float64(g.policy.MaxGrowthPerMin) {
+				return fmt.Sprintf("rss growing %.0f bytes/min >= limit %d", perMin, g.policy.MaxGrowthPerMin)
+			}
+		}
+	}
+
+	return ""
+}
+
+// slope returns the least-squares RSS growth in bytes per second
+func slope(samples []rssSample) float64 {
+	n := float64(len(samples))
+	origin := samples[0].at
+
+	var sumX, sumY, sumXY, sumXX float64
+	for _, s := range samples {
+		x := s.at.Sub(origin).Seconds()
+		y := float64(s.rss)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denom
+}
+
+// terminateProcess sends SIGTERM so the worker finishes its current job first
+func terminateProcess(pid int32) error {
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return err
+	}
+	return p.Terminate()
+}
@@ -0,0 +1,106 @@
+package guard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/probes"
+)
+
+const queueWork = "php artisan queue:work redis --queue=default"
+
+func newTestGuard(policy MemoryPolicy) (*MemoryGuard, *[]int32) {
+	g := NewMemoryGuard(policy)
+	var terminated []int32
+	g.terminate = func(pid int32) error {
+		terminated = append(terminated, pid)
+		return nil
+	}
+	return g, &terminated
+}
+
+func TestMemoryGuardHardLimitRespectsConcurrency(t *testing.T) {
+	g, terminated := newTestGuard(MemoryPolicy{MaxRSS: 100, MaxConcurrent: 1, Cooldown: time.Minute})
+	now := time.Now()
+
+	workers := []probes.LaravelWorkerDetail{
+		{PID: 1, Memory: 150, Cmdline: queueWork},
+		{PID: 2, Memory: 200, Cmdline: queueWork},
+		{PID: 3, Memory: 50, Cmdline: queueWork},
+	}
+
+	events := g.Evaluate(workers, now)
+	if len(events) != 1 || len(*terminated) != 1 || (*terminated)[0] != 1 {
+		t.Fatalf("Expected only PID 1 recycled, got %v (events %d)", *terminated, len(events))
+	}
+	if events[0].Type != EventWorkerRecycled {
+		t.Errorf("Expected %s event, got %s", EventWorkerRecycled, events[0].Type)
+	}
+	if events[0].Data["expected"] != true {
+		t.Errorf("Expected recycle to be marked expected, got %v", events[0].Data)
+	}
+
+	// PID 1 still shutting down: the cap blocks PID 2
+	g.Evaluate(workers, now.Add(10*time.Second))
+	if len(*terminated) != 1 {
+		t.Fatalf("Expected no new recycle while one is in flight, got %v", *terminated)
+	}
+
+	// PID 1 gone, but cooldown not yet elapsed since the last recycle
+	g.Evaluate(workers[1:], now.Add(30*time.Second))
+	if len(*terminated) != 1 {
+		t.Fatalf("Expected cooldown to block recycle, got %v", *terminated)
+	}
+
+	g.Evaluate(workers[1:], now.Add(3*time.Minute))
+	if len(*terminated) != 2 || (*terminated)[1] != 2 {
+		t.Errorf("Expected PID 2 recycled after cooldown, got %v", *terminated)
+	}
+}
+
+func TestMemoryGuardGrowthTrend(t *testing.T) {
+	g, terminated := newTestGuard(MemoryPolicy{MaxGrowthPerMin: 1000, TrendWindow: 4 * time.Minute})
+	now := time.Now()
+
+	// Growing 2000 bytes/min, but not enough history yet
+	for i := 0; i < 2; i++ {
+		g.Evaluate([]probes.LaravelWorkerDetail{{PID: 7, Memory: uint64(10000 + 2000*i), Cmdline: queueWork}}, now.Add(time.Duration(i)*time.Minute))
+	}
+	if len(*terminated) != 0 {
+		t.Fatalf("Expected no recycle without enough history, got %v", *terminated)
+	}
+
+	g.Evaluate([]probes.LaravelWorkerDetail{{PID: 7, Memory: 14000, Cmdline: queueWork}}, now.Add(2*time.Minute))
+	if len(*terminated) != 1 {
+		t.Errorf("Expected leaking worker to be recycled, got %v", *terminated)
+	}
+
+	// A flat worker is left alone
+	for i := 0; i < 5; i++ {
+		g.Evaluate([]probes.LaravelWorkerDetail{{PID: 8, Memory: 10000, Cmdline: queueWork}}, now.Add(time.Duration(10+i)*time.Minute))
+	}
+	if len(*terminated) != 1 {
+		t.Errorf("Expected stable worker untouched, got %v", *terminated)
+	}
+}
+
+func TestMemoryGuardSkipsHorizonMasterAndSupervisors(t *testing.T) {
+	g, terminated := newTestGuard(MemoryPolicy{MaxRSS: 100, MaxConcurrent: 5, Cooldown: time.Nanosecond})
+	now := time.Now()
+
+	workers := []probes.LaravelWorkerDetail{
+		{PID: 1, Memory: 500, Cmdline: "php artisan horizon"},
+		{PID: 2, Memory: 500, Cmdline: "php artisan horizon:supervisor web-1:supervisor-1 redis --queue=default"},
+		{PID: 3, Memory: 500, Cmdline: "php artisan horizon:work redis --name=default --supervisor=web-1:supervisor-1 --queue=default"},
+	}
+
+	g.Evaluate(workers, now)
+	if len(*terminated) != 1 || (*terminated)[0] != 3 {
+		t.Errorf("Expected only the horizon:work process recycled, got %v", *terminated)
+	}
+
+	g.Evaluate([]probes.LaravelWorkerDetail{{PID: 4, Memory: 500, Cmdline: queueWork}}, now.Add(time.Second))
+	if len(*terminated) != 2 || (*terminated)[1] != 4 {
+		t.Errorf("Expected queue:work process recycled, got %v", *terminated)
+	}
+}
@@ -0,0 +1,97 @@
+package laravel
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var envInterpolation = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// LoadEnv reads a project's .env file
+func LoadEnv(root string) (map[string]string, error) {
+	f, err := os.Open(filepath.Join(root, ".env"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseEnv(f)
+}
+
+// ParseEnv parses a .env file the way phpdotenv does for common cases:
+// comments, "export" prefixes, single quotes (literal), double quotes
+// (escapes) and ${VAR} interpolation of earlier variables.
+func ParseEnv(r io.Reader) (map[string]string, error) {
+	env := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		env[key] = parseEnvValue(strings.TrimSpace(value), env)
+	}
+	return env, scanner.Err()
+}
+
+func parseEnvValue(value string, env map[string]string) string {
+	if value == "" {
+		return ""
+	}
+
+	switch value[0] {
+	case '\'':
+		if end := strings.IndexByte(value[1:], '\''); end >= 0 {
+			return value[1 : end+1]
+		}
+		return value[1:]
+	case '"':
+		var b strings.Builder
+		for i := 1; i < len(value); i++ {
+			c := value[i]
+			if c == '"' {
+				break
+			}
+			if c == '\\' && i+1 < len(value) {
+				i++
+				switch value[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 't':
+					b.WriteByte('\t')
+				default:
+					b.WriteByte(value[i])
+				}
+				continue
+			}
+			b.WriteByte(c)
+		}
+		return interpolateEnv(b.String(), env)
+	}
+
+	// Unquoted: an inline comment starts at " #"
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return interpolateEnv(value, env)
+}
+
+func interpolateEnv(value string, env map[string]string) string {
+	return envInterpolation.ReplaceAllStringFunc(value, func(m string) string {
+		name := m[2 : len(m)-1]
+		if v, ok := env[name]; ok {
+			return v
+		}
+		return os.Getenv(name)
+	})
+}
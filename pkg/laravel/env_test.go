@@ -0,0 +1,49 @@
+package laravel
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseEnv(t *testing.T) {
+	input := `# Application
+APP_NAME="My Shop"
+export APP_ENV=production
+REDIS_PREFIX=
+REDIS_HOST=10.0.0.5 # primary
+CACHE_PREFIX='${APP_NAME}_literal'
+MAIL_FROM="${APP_NAME} <shop@example.com>"
+ESCAPED="say \"hi\"\n"
+not a variable
+`
+
+	env, err := ParseEnv(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"APP_NAME", "My Shop"},
+		{"APP_ENV", "production"},
+		{"REDIS_PREFIX", ""},
+		{"REDIS_HOST", "10.0.0.5"},
+		{"CACHE_PREFIX", "${APP_NAME}_literal"},
+		{"MAIL_FROM", "My Shop <shop@example.com>"},
+		{"ESCAPED", "say \"hi\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			value, ok := env[tt.key]
+			if !ok || value != tt.expected {
+				t.Errorf("Expected %q, got %q (set: %v)", tt.expected, value, ok)
+			}
+		})
+	}
+	if len(env) != len(tests) {
+		t.Errorf("Expected %d variables, got %v", len(tests), env)
+	}
+}
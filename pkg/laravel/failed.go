@@ -0,0 +1,163 @@
+package laravel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrFailedJobNotFound is returned when no failed job matches an ID or UUID
+var ErrFailedJobNotFound = errors.New("failed job not found")
+
+// FailedJob is a record from Laravel's failed job provider
+type FailedJob struct {
+	ID         string
+	UUID       string
+	Connection string
+	Queue      string
+	Payload    string
+	Exception  string
+	FailedAt   time.Time
+}
+
+// FailedJobStore reads and removes failed job records
+type FailedJobStore interface {
+	// Find returns a failed job by ID or UUID
+	Find(ctx context.Context, id string) (*FailedJob, error)
+	// IDs returns the IDs of all failed jobs, oldest first
+	IDs(ctx context.Context) ([]string, error)
+	// List returns a page of the failed jobs of a queue, newest first, and
+	// the number of failed jobs on that queue
+	List(ctx context.Context, queue string, offset, limit int) ([]FailedJob, int64, error)
+	// Forget deletes a failed job record
+	Forget(ctx context.Context, id string) error
+	// Close releases the store's resources
+	Close() error
+}
+
+// FailedStores keeps one failed job store per project, opened on first use,
+// so a project's failed jobs are never read from another project's store
+type FailedStores struct {
+	open   func(Project) (FailedJobStore, error)
+	mu     sync.Mutex
+	stores map[string]FailedJobStore // By canonical root
+}
+
+// NewFailedStores creates a registry. open returns a project's store, or
+// nil if it has none; neither nil stores nor errors are cached.
+func NewFailedStores(open func(Project) (FailedJobStore, error)) *FailedStores {
+	return &FailedStores{open: open, stores: make(map[string]FailedJobStore)}
+}
+
+// For returns the project's failed job store, or nil if it has none. A nil
+// registry has no stores.
+func (s *FailedStores) For(project Project) (FailedJobStore, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	root := canonicalRoot(project.Root)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if store, ok := s.stores[root]; ok {
+		return store, nil
+	}
+	store, err := s.open(project)
+	if err != nil || store == nil {
+		return nil, err
+	}
+	s.stores[root] = store
+	return store, nil
+}
+
+// Close closes every opened store
+func (s *FailedStores) Close() error {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var errs []error
+	for root, store := range s.stores {
+		errs = append(errs, store.Close())
+		delete(s.stores, root)
+	}
+	return errors.Join(errs...)
+}
+
+// Retry pushes a failed job back onto its queue the way `php artisan queue:retry`
+// does (attempts reset to 0) and then removes the failed record.
+// Unlike artisan, retryUntil() can't be re-evaluated outside PHP, so the
+// payload keeps its original retryUntil. The job goes back to the Redis
+// database and key prefix of the connection it failed on, looked up in
+// settings; client returns a Redis client on a database.
+func Retry(ctx context.Context, store FailedJobStore, settings *Settings, client func(db int) *redis.Client, id string) (*FailedJob, error) {
+	job, err := store.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := settings.QueueRedis(job.Connection)
+	if err != nil {
+		return job, err
+	}
+	redisClient := client(conn.DB)
+	if redisClient == nil {
+		return job, fmt.Errorf("no Redis client for database %d", conn.DB)
+	}
+
+	payload, err := ResetAttempts(job.Payload)
+	if err != nil {
+		return job, fmt.Errorf("invalid payload: %w", err)
+	}
+
+	if err := PushRaw(ctx, redisClient, conn.Prefix, job.Queue, payload); err != nil {
+		return job, fmt.Errorf("failed to push job: %w", err)
+	}
+	if err := store.Forget(ctx, job.ID); err != nil {
+		return job, fmt.Errorf("job was pushed but the failed record could not be removed: %w", err)
+	}
+
+	return job, nil
+}
+
+// PushRaw pushes a payload like Laravel's RedisQueue::pushRaw: onto
+// queues:{queue} plus a token on queues:{queue}:notify for blocking pops
+func PushRaw(ctx context.Context, client *redis.Client, keyPrefix, queue, payload string) error {
+	key := keyPrefix + "queues:" + queue
+
+	_, err := client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.RPush(ctx, key, payload)
+		pipe.RPush(ctx, key+":notify", 1)
+		return nil
+	})
+	return err
+}
+
+// ResetAttempts sets the payload's attempts back to 0 if present, like
+// RetryCommand::resetAttempts. Other fields are kept byte-for-byte.
+func ResetAttempts(payload string) (string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(payload), &fields); err != nil {
+		return "", err
+	}
+
+	if attempts, ok := fields["attempts"]; !ok || string(attempts) == "null" {
+		return payload, nil
+	}
+	fields["attempts"] = json.RawMessage("0")
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(fields); err != nil {
+		return "", err
+	}
+	return string(bytes.TrimRight(buf.Bytes(), "\n")), nil
+}
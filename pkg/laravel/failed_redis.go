@@ -0,0 +1,150 @@
+package laravel
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisFailedStore reads failed jobs recorded by Laravel Horizon:
+// {prefix}failed_jobs (sorted set of IDs) and a {prefix}{id} hash per job
+type RedisFailedStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisFailedStore creates a Horizon failed job store (prefix default: "horizon:")
+func NewRedisFailedStore(client *redis.Client, prefix string) *RedisFailedStore {
+	if prefix == "" {
+		prefix = "horizon:"
+	}
+	return &RedisFailedStore{client: client, prefix: prefix}
+}
+
+// failedJobFields are the job hash fields failedJobFromFields reads, in order
+var failedJobFields = []string{"status", "connection", "queue", "payload", "exception", "failed_at"}
+
+// failedJobFromFields builds a failed job from an HMGET of failedJobFields.
+// It returns nil if the record is gone or no longer failed.
+func failedJobFromFields(id string, values []interface{}) *FailedJob {
+	field := func(i int) string {
+		v, _ := values[i].(string)
+		return v
+	}
+	if field(0) != "failed" || field(3) == "" {
+		return nil
+	}
+
+	job := &FailedJob{
+		ID:         id,
+		UUID:       id,
+		Connection: field(1),
+		Queue:      field(2),
+		Payload:    field(3),
+		Exception:  field(4),
+	}
+	if ts, err := strconv.ParseFloat(field(5), 64); err == nil {
+		job.FailedAt = time.Unix(int64(ts), 0)
+	}
+	return job
+}
+
+// Find returns a failed job by ID (Horizon uses the job UUID as its ID)
+func (s *RedisFailedStore) Find(ctx context.Context, id string) (*FailedJob, error) {
+	values, err := s.client.HMGet(ctx, s.prefix+id, failedJobFields...).Result()
+	if err != nil {
+		return nil, err
+	}
+	job := failedJobFromFields(id, values)
+	if job == nil {
+		return nil, ErrFailedJobNotFound
+	}
+	return job, nil
+}
+
+// IDs returns all failed job IDs, oldest first.
+// Horizon scores failed jobs by negative timestamp, so the newest come first.
+func (s *RedisFailedStore) IDs(ctx context.Context) ([]string, error) {
+	return s.client.ZRevRange(ctx, s.prefix+"failed_jobs", 0, -1).Result()
+}
+
+// listChunk bounds the HMGETs sent in one pipeline while filtering by queue
+const listChunk = 500
+
+// List returns a page of the failed jobs of a queue, newest first. Horizon
+// has no per-queue index, so the queue of every failed job is read, in
+// pipelined chunks, before the page itself is fetched in one pipeline.
+func (s *RedisFailedStore) List(ctx context.Context, queue string, offset, limit int) ([]FailedJob, int64, error) {
+	// Negative timestamp scores: ascending order is newest first
+	ids, err := s.client.ZRange(ctx, s.prefix+"failed_jobs", 0, -1).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var matching []string
+	for start := 0; start < len(ids); start += listChunk {
+		chunk := ids[start:min(start+listChunk, len(ids))]
+		cmds, err := s.hmget(ctx, chunk, "status", "queue")
+		if err != nil {
+			return nil, 0, err
+		}
+		for i, cmd := range cmds {
+			values := cmd.Val()
+			if values[0] == "failed" && values[1] == queue {
+				matching = append(matching, chunk[i])
+			}
+		}
+	}
+
+	total := int64(len(matching))
+	if offset >= len(matching) {
+		return []FailedJob{}, total, nil
+	}
+	page := matching[offset:min(offset+limit, len(matching))]
+	cmds, err := s.hmget(ctx, page, failedJobFields...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	jobs := make([]FailedJob, 0, len(page))
+	for i, cmd := range cmds {
+		// Retried or forgotten since the queue was read
+		if job := failedJobFromFields(page[i], cmd.Val()); job != nil {
+			jobs = append(jobs, *job)
+		}
+	}
+	return jobs, total, nil
+}
+
+// hmget reads the same fields of several job hashes in one pipeline
+func (s *RedisFailedStore) hmget(ctx context.Context, ids []string, fields ...string) ([]*redis.SliceCmd, error) {
+	cmds := make([]*redis.SliceCmd, len(ids))
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, id := range ids {
+			cmds[i] = pipe.HMGet(ctx, s.prefix+id, fields...)
+		}
+		return nil
+	})
+	return cmds, err
+}
+
+// Forget removes a failed job from Horizon's indexes and deletes its record
+func (s *RedisFailedStore) Forget(ctx context.Context, id string) error {
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, s.prefix+"failed_jobs", id)
+		pipe.ZRem(ctx, s.prefix+"recent_failed_jobs", id)
+		pipe.Del(ctx, s.prefix+id)
+		return nil
+	})
+	return err
+}
+
+// Close is a no-op: the Redis client is owned by the agent
+func (s *RedisFailedStore) Close() error {
+	return nil
+}
+
+// Ensure RedisFailedStore implements FailedJobStore
+var _ FailedJobStore = (*RedisFailedStore)(nil)
@@ -0,0 +1,220 @@
+package laravel
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestResetAttempts(t *testing.T) {
+	raw, err := os.ReadFile(filepath.Join("testdata", "send_invoice.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reset, err := ResetAttempts(string(raw))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	p, err := DecodePayload(reset)
+	if err != nil {
+		t.Fatalf("Reset payload no longer decodes: %v", err)
+	}
+	if p.Attempts != 0 {
+		t.Errorf("Expected attempts 0, got %d", p.Attempts)
+	}
+
+	// The serialized command must survive untouched, escapes included
+	original, _ := DecodePayload(string(raw))
+	if p.Command != original.Command || p.UUID != original.UUID {
+		t.Error("Expected command and uuid to be preserved")
+	}
+	if !strings.Contains(reset, `\u0000*\u0000copies`) {
+		t.Error("Expected original JSON escapes to be preserved")
+	}
+
+	// Payloads without attempts are returned as-is
+	noAttempts := `{"uuid":"x","job":"a<b"}`
+	if got, _ := ResetAttempts(noAttempts); got != noAttempts {
+		t.Errorf("Expected payload unchanged, got %s", got)
+	}
+
+	if _, err := ResetAttempts("not json"); err == nil {
+		t.Error("Expected error for invalid payload")
+	}
+}
+
+func TestSQLSource(t *testing.T) {
+	tests := []struct {
+		dsn      string
+		driver   string
+		source   string
+		hasError bool
+	}{
+		{"mysql://app:s3cret@db:3307/shop", "mysql", "app:s3cret@tcp(db:3307)/shop?parseTime=true", false},
+		{"mysql://app@db/shop", "mysql", "app@tcp(db:3306)/shop?parseTime=true", false},
+		{"postgres://app:pw@db:5432/shop?sslmode=disable", "postgres", "postgres://app:pw@db:5432/shop?sslmode=disable", false},
+		{"sqlite:///var/www/database.sqlite", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsn, func(t *testing.T) {
+			driver, source, err := sqlSource(tt.dsn)
+			if (err != nil) != tt.hasError {
+				t.Fatalf("Expected error=%v, got %v", tt.hasError, err)
+			}
+			if driver != tt.driver || source != tt.source {
+				t.Errorf("Expected %s %s, got %s %s", tt.driver, tt.source, driver, source)
+			}
+		})
+	}
+}
+
+func TestNewDatabaseFailedStoreTableName(t *testing.T) {
+	if _, err := NewDatabaseFailedStore("mysql://app@db/shop", "failed_jobs; DROP TABLE users"); err == nil {
+		t.Error("Expected invalid table name to be rejected")
+	}
+}
+
+func TestFailedStoresPerProject(t *testing.T) {
+	opened := make(map[string]int)
+	stores := NewFailedStores(func(project Project) (FailedJobStore, error) {
+		opened[project.Name]++
+		if project.Name == "blog" {
+			return nil, nil // No failed job store
+		}
+		return NewRedisFailedStore(nil, project.Name+"_horizon:"), nil
+	})
+
+	shop, _ := stores.For(Project{Name: "shop", Root: "/srv/shop"})
+	again, _ := stores.For(Project{Name: "shop", Root: "/srv/shop/"})
+	api, _ := stores.For(Project{Name: "api", Root: "/srv/api"})
+	if shop == nil || shop != again {
+		t.Errorf("Expected one store for shop, got %v and %v", shop, again)
+	}
+	if api == nil || api == shop {
+		t.Errorf("Expected api to have its own store, got %v", api)
+	}
+
+	for i := 0; i < 2; i++ {
+		if store, err := stores.For(Project{Name: "blog", Root: "/srv/blog"}); store != nil || err != nil {
+			t.Errorf("Expected no store for blog, got %v (%v)", store, err)
+		}
+	}
+	if opened["shop"] != 1 || opened["blog"] != 2 {
+		t.Errorf("Expected stores to be cached and missing ones retried, got %v", opened)
+	}
+
+	var none *FailedStores
+	if store, err := none.For(Project{Root: "/srv/shop"}); store != nil || err != nil {
+		t.Errorf("Expected nil registry to have no stores, got %v (%v)", store, err)
+	}
+}
+
+func TestRedisFailedStoreList(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	store := NewRedisFailedStore(client, "horizon:")
+
+	// Jobs 1..5, failing one second apart; job 3 is on another queue and
+	// job 4 has been retried
+	for i := 1; i <= 5; i++ {
+		id := fmt.Sprintf("job-%d", i)
+		queue, status := "default", "failed"
+		if i == 3 {
+			queue = "emails"
+		}
+		if i == 4 {
+			status = "completed"
+		}
+		failedAt := 1700000000 + i
+		mr.ZAdd("horizon:failed_jobs", float64(-failedAt), id)
+		mr.HSet("horizon:"+id, "status", status, "queue", queue, "connection", "redis",
+			"payload", `{"uuid":"`+id+`"}`, "failed_at", fmt.Sprint(failedAt))
+	}
+
+	jobs, total, err := store.List(context.Background(), "default", 1, 5)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if total != 3 {
+		t.Errorf("Expected 3 failed jobs on default, got %d", total)
+	}
+
+	var ids []string
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	if strings.Join(ids, ",") != "job-2,job-1" {
+		t.Errorf("Expected job-2,job-1 newest first, got %v", ids)
+	}
+	if jobs[0].Connection != "redis" || jobs[0].FailedAt.Unix() != 1700000002 {
+		t.Errorf("Unexpected job: %+v", jobs[0])
+	}
+
+	if jobs, total, err := store.List(context.Background(), "default", 3, 5); err != nil || total != 3 || len(jobs) != 0 {
+		t.Errorf("Expected an empty page past the end, got %d of %d (%v)", len(jobs), total, err)
+	}
+}
+
+func TestRetryUsesTheJobsConnection(t *testing.T) {
+	mr := miniredis.RunT(t)
+	base := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer base.Close()
+	clients := map[int]*redis.Client{}
+	client := func(db int) *redis.Client {
+		if clients[db] == nil {
+			clients[db] = RedisClient(base, db)
+		}
+		return clients[db]
+	}
+	defer func() {
+		for _, c := range clients {
+			if c != base {
+				_ = c.Close()
+			}
+		}
+	}()
+
+	settings := &Settings{
+		QueueConnection: "redis",
+		QueueDriver:     "redis",
+		Redis:           RedisSettings{Connection: "default", DB: 0, Prefix: "app_"},
+		RedisConnections: map[string]RedisSettings{
+			"redis":      {Connection: "default", DB: 0, Prefix: "app_"},
+			"redis-long": {Connection: "long", DB: 2, Prefix: "long_"},
+		},
+	}
+	store := NewRedisFailedStore(base, "horizon:")
+	for id, connection := range map[string]string{"a": "redis-long", "b": "database"} {
+		mr.ZAdd("horizon:failed_jobs", -1, id)
+		mr.HSet("horizon:"+id, "status", "failed", "queue", "reports", "connection", connection, "payload", `{"attempts":3}`)
+	}
+
+	if _, err := Retry(context.Background(), store, settings, client, "a"); err != nil {
+		t.Fatalf("Retry failed: %v", err)
+	}
+	mr.Select(2)
+	if items, _ := mr.List("long_queues:reports"); len(items) != 1 || items[0] != `{"attempts":0}` {
+		t.Errorf("Expected the job on long_queues:reports in db 2, got %v", items)
+	}
+	mr.Select(0)
+	if mr.Exists("app_queues:reports") {
+		t.Error("Expected nothing pushed to the default connection")
+	}
+
+	if _, err := Retry(context.Background(), store, settings, client, "b"); err == nil {
+		t.Error("Expected an error for a job of a connection that isn't Redis")
+	}
+	if _, err := store.Find(context.Background(), "b"); err != nil {
+		t.Errorf("Expected the failed record to be kept, got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package laravel
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Horizon supervisor commands, pushed onto {prefix}commands:{supervisor}
+const (
+	horizonPauseCommand    = `Laravel\Horizon\SupervisorCommands\Pause`
+	horizonContinueCommand = `Laravel\Horizon\SupervisorCommands\ContinueWorking`
+)
+
+// PauseKey returns the cache key Laravel 11's `queue:pause` sets.
+// cachePrefix is the full Redis key prefix of the cache store, e.g. "laravel_cache_"
+// (plus the Redis connection prefix, if the app uses one).
+func PauseKey(cachePrefix, connection, queue string) string {
+	return cachePrefix + "illuminate:queue:paused:" + connection + ":" + queue
+}
+
+// PauseQueue sets the pause flag forever, like QueueManager::pause.
+// The Redis cache store serializes true as "b:1;".
+func PauseQueue(ctx context.Context, client *redis.Client, cachePrefix, connection, queue string) error {
+	return client.Set(ctx, PauseKey(cachePrefix, connection, queue), "b:1;", 0).Err()
+}
+
+// ResumeQueue clears the pause flag, like QueueManager::resume
+func ResumeQueue(ctx context.Context, client *redis.Client, cachePrefix, connection, queue string) error {
+	return client.Del(ctx, PauseKey(cachePrefix, connection, queue)).Err()
+}
+
+// IsPaused reports whether the pause flag is set
+func IsPaused(ctx context.Context, client *redis.Client, cachePrefix, connection, queue string) (bool, error) {
+	value, err := client.Get(ctx, PauseKey(cachePrefix, connection, queue)).Result()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return PauseFlagSet(value), nil
+}
+
+// PauseFlagSet interprets a cached pause flag value the way (bool) does in PHP
+func PauseFlagSet(value string) bool {
+	v, err := Unserialize(value)
+	if err != nil {
+		// Numeric values are stored unserialized
+		return value != "" && value != "0"
+	}
+	switch v := v.(type) {
+	case bool:
+		return v
+	case int64:
+		return v != 0
+	case string:
+		return v != "" && v != "0"
+	}
+	return v != nil
+}
+
+// HorizonSupervisor is the state Horizon publishes for a running supervisor
+type HorizonSupervisor struct {
+	Name      string
+	Status    string         // "running" or "paused"
+	Processes map[string]int // "connection:queue" => process count
+}
+
+// HorizonSupervisors returns the supervisors Horizon currently reports
+func HorizonSupervisors(ctx context.Context, client *redis.Client, prefix string) ([]HorizonSupervisor, error) {
+	names, err := client.ZRange(ctx, prefix+"supervisors", 0, -1).Result()
+	if err != nil || len(names) == 0 {
+		return nil, err
+	}
+
+	pipe := client.Pipeline()
+	cmds := make([]*redis.SliceCmd, len(names))
+	for i, name := range names {
+		cmds[i] = pipe.HMGet(ctx, prefix+"supervisor:"+name, "status", "processes")
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	var supervisors []HorizonSupervisor
+	for i, cmd := range cmds {
+		values := cmd.Val()
+		status, _ := values[0].(string)
+		if status == "" {
+			// Record expired: supervisor is gone
+			continue
+		}
+
+		s := HorizonSupervisor{Name: names[i], Status: status}
+		if processes, ok := values[1].(string); ok {
+			_ = json.Unmarshal([]byte(processes), &s.Processes)
+		}
+		supervisors = append(supervisors, s)
+	}
+	return supervisors, nil
+}
+
+// HorizonQueuePaused reports whether every Horizon supervisor serving
+// connection:queue is paused. It is false when no supervisor serves it.
+func HorizonQueuePaused(supervisors []HorizonSupervisor, connection, queue string) bool {
+	serving := 0
+	for _, s := range supervisors {
+		if !servesQueue(s, connection, queue) {
+			continue
+		}
+		serving++
+		if s.Status != "paused" {
+			return false
+		}
+	}
+	return serving > 0
+}
+
+// servesQueue checks the supervisor's "connection:queue1,queue2" process pools
+func servesQueue(s HorizonSupervisor, connection, queue string) bool {
+	for pool := range s.Processes {
+		conn, queues, ok := strings.Cut(pool, ":")
+		if !ok || conn != connection {
+			continue
+		}
+		for _, q := range strings.Split(queues, ",") {
+			if q == queue {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PauseHorizonSupervisor asks a Horizon supervisor to pause (or continue) its workers
+func PauseHorizonSupervisor(ctx context.Context, client *redis.Client, prefix, name string, pause bool) error {
+	command := horizonContinueCommand
+	if pause {
+		command = horizonPauseCommand
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"command": command,
+		"options": []interface{}{},
+	})
+	if err != nil {
+		return err
+	}
+	return client.RPush(ctx, prefix+"commands:"+name, data).Err()
+}
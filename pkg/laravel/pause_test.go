@@ -0,0 +1,60 @@
+package laravel
+
+import "testing"
+
+func TestPauseKey(t *testing.T) {
+	got := PauseKey("laravel_database_laravel_cache_", "redis", "emails")
+	want := "laravel_database_laravel_cache_illuminate:queue:paused:redis:emails"
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestPauseFlagSet(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"b:1;", true},
+		{"b:0;", false},
+		{"i:1;", true},
+		{"1", true},
+		{"0", false},
+		{`s:0:"";`, false},
+	}
+
+	for _, tt := range tests {
+		if got := PauseFlagSet(tt.value); got != tt.want {
+			t.Errorf("PauseFlagSet(%q): expected %v, got %v", tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestHorizonQueuePaused(t *testing.T) {
+	supervisors := []HorizonSupervisor{
+		{Name: "web-1:supervisor-1", Status: "paused", Processes: map[string]int{"redis:default,emails": 3}},
+		{Name: "web-2:supervisor-1", Status: "running", Processes: map[string]int{"redis:default": 2}},
+		{Name: "web-1:supervisor-2", Status: "paused", Processes: map[string]int{"redis:reports": 1}},
+	}
+
+	tests := []struct {
+		name       string
+		connection string
+		queue      string
+		want       bool
+	}{
+		{"all serving supervisors paused", "redis", "emails", true},
+		{"one serving supervisor running", "redis", "default", false},
+		{"single paused supervisor", "redis", "reports", true},
+		{"other connection", "sqs", "emails", false},
+		{"unserved queue", "redis", "imports", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HorizonQueuePaused(supervisors, tt.connection, tt.queue); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
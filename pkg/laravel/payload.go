@@ -0,0 +1,203 @@
+// Package laravel decodes Laravel queue job payloads, including the
+// PHP-serialized job command they carry.
+package laravel
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrEncrypted is returned when the command of a ShouldBeEncrypted job is decoded
+var ErrEncrypted = errors.New("job command is encrypted")
+
+// ErrNoCommand is returned when the payload carries no serialized command
+// (e.g. plain "job@method" payloads)
+var ErrNoCommand = errors.New("payload has no serialized command")
+
+// Payload is the JSON envelope Laravel pushes onto a queue
+type Payload struct {
+	UUID          string
+	ID            string // Queue-assigned job ID (Redis driver)
+	DisplayName   string
+	Job           string // Handler, e.g. Illuminate\Queue\CallQueuedHandler@call
+	Attempts      int
+	MaxTries      *int
+	MaxExceptions *int
+	Timeout       *int
+	RetryUntil    *int64 // Unix seconds
+	PushedAt      time.Time
+	Tags          []string // Horizon tags
+	CommandName   string
+	Command       string // Raw PHP-serialized (or encrypted) command
+	Size          int    // Raw payload size in bytes
+}
+
+// rawPayload mirrors the JSON layout. Numeric fields use RawMessage because
+// Laravel emits null, numbers or strings depending on the version.
+type rawPayload struct {
+	UUID          string          `json:"uuid"`
+	ID            json.RawMessage `json:"id"`
+	DisplayName   string          `json:"displayName"`
+	Job           string          `json:"job"`
+	Attempts      json.RawMessage `json:"attempts"`
+	MaxTries      json.RawMessage `json:"maxTries"`
+	MaxExceptions json.RawMessage `json:"maxExceptions"`
+	Timeout       json.RawMessage `json:"timeout"`
+	RetryUntil    json.RawMessage `json:"retryUntil"`
+	PushedAt      json.RawMessage `json:"pushedAt"`
+	Tags          []string        `json:"tags"`
+	Data          struct {
+		CommandName string `json:"commandName"`
+		Command     string `json:"command"`
+	} `json:"data"`
+}
+
+// DecodePayload parses a raw Laravel job payload
+func DecodePayload(raw string) (*Payload, error) {
+	var r rawPayload
+	if err := json.Unmarshal([]byte(raw), &r); err != nil {
+		return nil, err
+	}
+
+	p := &Payload{
+		UUID:          r.UUID,
+		ID:            rawString(r.ID),
+		DisplayName:   r.DisplayName,
+		Job:           r.Job,
+		MaxTries:      rawInt(r.MaxTries),
+		MaxExceptions: rawInt(r.MaxExceptions),
+		Timeout:       rawInt(r.Timeout),
+		Tags:          r.Tags,
+		CommandName:   r.Data.CommandName,
+		Command:       r.Data.Command,
+		Size:          len(raw),
+	}
+	if n := rawInt(r.Attempts); n != nil {
+		p.Attempts = *n
+	}
+	if f, ok := rawFloat(r.RetryUntil); ok {
+		until := int64(f)
+		p.RetryUntil = &until
+	}
+	if f, ok := rawFloat(r.PushedAt); ok && f > 0 {
+		p.PushedAt = time.UnixMilli(int64(math.Round(f * 1000)))
+	}
+	if p.DisplayName == "" {
+		p.DisplayName = p.CommandName
+	}
+
+	return p, nil
+}
+
+// Matches reports whether jobKey identifies this job exactly (UUID or queue ID)
+func (p *Payload) Matches(jobKey string) bool {
+	return jobKey != "" && (jobKey == p.UUID || jobKey == p.ID)
+}
+
+// Encrypted reports whether the command is encrypted (ShouldBeEncrypted)
+func (p *Payload) Encrypted() bool {
+	// Encrypted commands are base64 JSON ({"iv":...}), serialized ones start with a type tag
+	return strings.HasPrefix(p.Command, "eyJ")
+}
+
+// DecodeCommand unserializes the job command object
+func (p *Payload) DecodeCommand() (*Object, error) {
+	if p.Command == "" {
+		return nil, ErrNoCommand
+	}
+	if p.Encrypted() {
+		return nil, ErrEncrypted
+	}
+
+	v, err := Unserialize(p.Command)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := v.(*Object)
+	if !ok {
+		return nil, errors.New("job command is not an object")
+	}
+	return obj, nil
+}
+
+// ModelIdentifier is a queued Eloquent model reference (SerializesModels)
+type ModelIdentifier struct {
+	Class      string
+	ID         interface{} // Key, or *Array of keys for collections
+	Connection string
+}
+
+const modelIdentifierClass = `Illuminate\Contracts\Database\ModelIdentifier`
+
+// ModelIdentifiers returns every model reference found in an unserialized value
+func ModelIdentifiers(v interface{}) []ModelIdentifier {
+	var models []ModelIdentifier
+
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case *Object:
+			if v.Class == modelIdentifierClass {
+				m := ModelIdentifier{}
+				if class, ok := v.Get("class"); ok {
+					m.Class, _ = class.(string)
+				}
+				m.ID, _ = v.Get("id")
+				if conn, ok := v.Get("connection"); ok {
+					m.Connection, _ = conn.(string)
+				}
+				models = append(models, m)
+				return
+			}
+			for _, prop := range v.Properties {
+				walk(prop.Value)
+			}
+		case *Array:
+			for _, e := range v.Entries {
+				walk(e.Value)
+			}
+		}
+	}
+	walk(v)
+
+	return models
+}
+
+// rawString returns a JSON string or number as a string
+func rawString(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n.String()
+	}
+	return ""
+}
+
+// rawFloat parses a JSON number or numeric string
+func rawFloat(raw json.RawMessage) (float64, bool) {
+	s := rawString(raw)
+	if s == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	return f, err == nil
+}
+
+func rawInt(raw json.RawMessage) *int {
+	f, ok := rawFloat(raw)
+	if !ok {
+		return nil
+	}
+	n := int(f)
+	return &n
+}
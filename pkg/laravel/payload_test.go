@@ -0,0 +1,119 @@
+package laravel
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func loadFixture(t *testing.T, name string) *Payload {
+	t.Helper()
+
+	raw, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := DecodePayload(string(raw))
+	if err != nil {
+		t.Fatalf("Failed to decode %s: %v", name, err)
+	}
+	return p
+}
+
+func TestDecodePayloadJob(t *testing.T) {
+	p := loadFixture(t, "send_invoice.json")
+
+	if p.UUID != "5b2fb4a8-2c3e-4d4b-9b57-0a6c4c0f7e21" || p.ID != "Q8yUw3cZ1n0kLmVh2RaT5dXe7gBj9kPo" {
+		t.Errorf("Unexpected identifiers: %s / %s", p.UUID, p.ID)
+	}
+	if p.DisplayName != `App\Jobs\SendInvoice` {
+		t.Errorf("Expected display name App\\Jobs\\SendInvoice, got %s", p.DisplayName)
+	}
+	if p.Attempts != 1 || p.MaxTries == nil || *p.MaxTries != 3 || p.MaxExceptions != nil {
+		t.Errorf("Unexpected attempts/maxTries/maxExceptions: %d %v %v", p.Attempts, p.MaxTries, p.MaxExceptions)
+	}
+	if p.Timeout == nil || *p.Timeout != 120 {
+		t.Errorf("Expected timeout 120, got %v", p.Timeout)
+	}
+	if !p.PushedAt.IsZero() {
+		t.Errorf("Expected no pushedAt, got %v", p.PushedAt)
+	}
+	if !p.Matches(p.UUID) || !p.Matches(p.ID) || p.Matches("5b2fb4a8") {
+		t.Error("Expected exact matching on UUID and ID only")
+	}
+
+	cmd, err := p.DecodeCommand()
+	if err != nil {
+		t.Fatalf("Failed to decode command: %v", err)
+	}
+	if cmd.Class != `App\Jobs\SendInvoice` {
+		t.Errorf("Expected command class App\\Jobs\\SendInvoice, got %s", cmd.Class)
+	}
+	if queue, _ := cmd.Get("queue"); queue != "invoices" {
+		t.Errorf("Expected queue invoices, got %v", queue)
+	}
+	if locale, _ := cmd.Get("locale"); locale != "de_CH" {
+		t.Errorf("Expected private locale de_CH, got %v", locale)
+	}
+
+	models := ModelIdentifiers(cmd)
+	if len(models) != 1 || models[0].Class != `App\Models\Invoice` || models[0].ID != int64(42) || models[0].Connection != "mysql" {
+		t.Errorf("Expected Invoice 42 model identifier, got %+v", models)
+	}
+}
+
+func TestDecodePayloadListener(t *testing.T) {
+	p := loadFixture(t, "queued_listener.json")
+
+	if p.MaxTries != nil || p.Timeout != nil {
+		t.Errorf("Expected null maxTries and timeout, got %v %v", p.MaxTries, p.Timeout)
+	}
+	if p.RetryUntil == nil || *p.RetryUntil != 1700003600 {
+		t.Errorf("Expected retryUntil 1700003600, got %v", p.RetryUntil)
+	}
+	if want := time.UnixMilli(1700000000512); !p.PushedAt.Equal(want) {
+		t.Errorf("Expected pushedAt %v, got %v", want, p.PushedAt)
+	}
+	if len(p.Tags) != 2 || p.Tags[0] != `App\Models\Order:1001` {
+		t.Errorf("Unexpected tags %v", p.Tags)
+	}
+
+	cmd, err := p.DecodeCommand()
+	if err != nil {
+		t.Fatalf("Failed to decode command: %v", err)
+	}
+	if class, _ := cmd.Get("class"); class != `App\Listeners\NotifyCustomer` {
+		t.Errorf("Expected listener class, got %v", class)
+	}
+
+	data, _ := cmd.Get("data")
+	event, _ := data.(*Array).Get(0)
+	status, _ := event.(*Object).Get("status")
+	if status != (Enum{Class: `App\Enums\OrderStatus`, Case: "Shipped"}) {
+		t.Errorf("Expected Shipped enum, got %#v", status)
+	}
+
+	models := ModelIdentifiers(cmd)
+	if len(models) != 2 {
+		t.Fatalf("Expected 2 model identifiers, got %+v", models)
+	}
+	if ids, ok := models[1].ID.(*Array); !ok || len(ids.Entries) != 3 {
+		t.Errorf("Expected collection of 3 ids, got %#v", models[1].ID)
+	}
+}
+
+func TestDecodePayloadEncrypted(t *testing.T) {
+	p := loadFixture(t, "encrypted_job.json")
+
+	if !p.Encrypted() {
+		t.Error("Expected payload to be encrypted")
+	}
+	if _, err := p.DecodeCommand(); !errors.Is(err, ErrEncrypted) {
+		t.Errorf("Expected ErrEncrypted, got %v", err)
+	}
+	if p.Attempts != 3 || p.DisplayName != `App\Jobs\ChargeCard` {
+		t.Errorf("Expected envelope fields to decode, got %+v", p)
+	}
+}
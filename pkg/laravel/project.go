@@ -0,0 +1,288 @@
+package laravel
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Project is a Laravel application on this host
+type Project struct {
+	Name       string `json:"name"`
+	Root       string `json:"root"` // Directory containing artisan
+	PHP        string `json:"php"`  // PHP binary
+	Discovered bool   `json:"discovered,omitempty"`
+
+	Settings *Settings `json:"settings,omitempty"` // Detected queue settings (nil when detection is off or failed)
+}
+
+// Projects resolves command targets among configured projects and those
+// discovered from running workers
+type Projects struct {
+	configured []Project
+	discover   func() ([]Project, error)
+
+	detect    func(Project) *Settings
+	mu        sync.Mutex
+	settings  map[string]*Settings     // Detected settings by canonical root
+	failedAt  map[string]time.Time     // Last failed detection by canonical root
+	detecting map[string]chan struct{} // Detections in progress by canonical root
+	names     map[string]string        // Names given to discovered projects by canonical root
+}
+
+// detectRetry is how long a failed detection is not tried again, so a
+// broken project doesn't boot PHP on every lookup
+var detectRetry = time.Minute
+
+// NewProjects creates a project registry. discover is called on every
+// lookup so new deployments are picked up (optional).
+func NewProjects(configured []Project, discover func() ([]Project, error)) *Projects {
+	return &Projects{configured: configured, discover: discover}
+}
+
+// WithDetection enables settings detection. detect runs once per project
+// root until it succeeds; its result (nil on failure) is attached to the
+// projects returned by List and Resolve.
+func (p *Projects) WithDetection(detect func(Project) *Settings) *Projects {
+	p.detect = detect
+	p.settings = make(map[string]*Settings)
+	p.failedAt = make(map[string]time.Time)
+	p.detecting = make(map[string]chan struct{})
+	return p
+}
+
+// Configured returns the explicitly configured projects
+func (p *Projects) Configured() []Project {
+	return p.configured
+}
+
+// withSettings attaches detected settings to the projects
+func (p *Projects) withSettings(projects []Project) []Project {
+	if p.detect == nil {
+		return projects
+	}
+
+	for i, project := range projects {
+		projects[i].Settings = p.settingsFor(project)
+	}
+	return projects
+}
+
+// settingsFor returns a project's detected settings, detecting them unless
+// they are known or failed recently. Detection runs without holding the
+// lock, since booting an app can take a while; concurrent lookups of the
+// same root wait for it instead of detecting again.
+func (p *Projects) settingsFor(project Project) *Settings {
+	root := canonicalRoot(project.Root)
+	for {
+		p.mu.Lock()
+		if settings, ok := p.settings[root]; ok {
+			p.mu.Unlock()
+			return settings
+		}
+		if failedAt, ok := p.failedAt[root]; ok && time.Since(failedAt) < detectRetry {
+			p.mu.Unlock()
+			return nil
+		}
+		done, busy := p.detecting[root]
+		if !busy {
+			done = make(chan struct{})
+			p.detecting[root] = done
+		}
+		p.mu.Unlock()
+
+		if busy {
+			<-done
+			continue
+		}
+
+		settings := p.detect(project)
+
+		p.mu.Lock()
+		if settings != nil {
+			p.settings[root] = settings
+			delete(p.failedAt, root)
+		} else {
+			p.failedAt[root] = time.Now()
+		}
+		delete(p.detecting, root)
+		close(done)
+		p.mu.Unlock()
+		return settings
+	}
+}
+
+// List returns configured projects followed by discovered ones whose root
+// is not configured, sorted by name
+func (p *Projects) List() ([]Project, error) {
+	projects := append([]Project(nil), p.configured...)
+	if p.discover == nil {
+		return p.withSettings(projects), nil
+	}
+
+	discovered, err := p.discover()
+	if err != nil {
+		return p.withSettings(projects), err
+	}
+
+	known := make(map[string]bool)
+	taken := make(map[string]bool)
+	for _, project := range projects {
+		known[canonicalRoot(project.Root)] = true
+		taken[project.Name] = true
+	}
+	var extra []Project
+	for _, project := range discovered {
+		if root := canonicalRoot(project.Root); !known[root] {
+			known[root] = true
+			project.Discovered = true
+			extra = append(extra, project)
+		}
+	}
+
+	// Names must be unique to be usable as targets; by root for stable names
+	sort.Slice(extra, func(i, j int) bool { return extra[i].Root < extra[j].Root })
+	names := make(map[string]string, len(extra))
+	for i := range extra {
+		extra[i].Name = uniqueName(extra[i], taken)
+		taken[extra[i].Name] = true
+		names[canonicalRoot(extra[i].Root)] = extra[i].Name
+	}
+	sort.Slice(extra, func(i, j int) bool { return extra[i].Name < extra[j].Name })
+
+	p.mu.Lock()
+	p.names = names
+	p.mu.Unlock()
+
+	return p.withSettings(append(projects, extra...)), nil
+}
+
+// uniqueName returns the project's name, or if it is taken the name
+// qualified with its deploy root or release directory, or numbered
+func uniqueName(project Project, taken map[string]bool) string {
+	name := project.Name
+	if !taken[name] {
+		return name
+	}
+	for _, dir := range []string{DeployRoot(project.Root), project.Root} {
+		if base := filepath.Base(filepath.Clean(dir)); base != name {
+			if candidate := name + "-" + base; !taken[candidate] {
+				return candidate
+			}
+		}
+	}
+	for i := 2; ; i++ {
+		if candidate := fmt.Sprintf("%s-%d", name, i); !taken[candidate] {
+			return candidate
+		}
+	}
+}
+
+// Resolve finds the target project by name or root. Without a name it
+// succeeds only if the host runs exactly one project.
+func (p *Projects) Resolve(name string) (Project, error) {
+	projects, err := p.List()
+	if len(projects) == 0 {
+		if err != nil {
+			return Project{}, err
+		}
+		return Project{}, fmt.Errorf("no projects configured and no running artisan processes found")
+	}
+
+	if name == "" {
+		if len(projects) > 1 {
+			return Project{}, fmt.Errorf("several Laravel projects on this host (%s), set project in payload", projectNames(projects))
+		}
+		return projects[0], nil
+	}
+
+	for _, project := range projects {
+		if project.Name == name {
+			return project, nil
+		}
+	}
+	for _, project := range projects {
+		if SameRoot(project.Root, name) {
+			return project, nil
+		}
+	}
+	return Project{}, fmt.Errorf("unknown project %s (known: %s)", name, projectNames(projects))
+}
+
+// NameFor returns the project name for a root: the configured name, or the
+// name List gave the discovered project
+func (p *Projects) NameFor(root string) string {
+	for _, project := range p.configured {
+		if SameRoot(project.Root, root) {
+			return project.Name
+		}
+	}
+
+	canonical := canonicalRoot(root)
+	if name, ok := p.discoveredName(canonical); ok {
+		return name
+	}
+	if p.discover != nil {
+		// A project started since the last List
+		_, _ = p.List()
+		if name, ok := p.discoveredName(canonical); ok {
+			return name
+		}
+	}
+	return ProjectName(root)
+}
+
+func (p *Projects) discoveredName(root string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	name, ok := p.names[root]
+	return name, ok
+}
+
+// SameRoot reports whether two paths are the same project directory.
+// Worker cwds are symlink-resolved, so a configured ".../current" root
+// must be resolved before comparing.
+func SameRoot(a, b string) bool {
+	return canonicalRoot(a) == canonicalRoot(b)
+}
+
+func canonicalRoot(root string) string {
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		return resolved
+	}
+	return filepath.Clean(root)
+}
+
+// ProjectName derives a project name from its root: the slug of the app's
+// APP_NAME, unless it is Laravel's default, or else the name of its deploy
+// root, so releases of one app share a name
+func ProjectName(root string) string {
+	if env, err := LoadEnv(root); err == nil {
+		if name := Slug(env["APP_NAME"]); name != "" && name != "laravel" {
+			return name
+		}
+	}
+	return filepath.Base(DeployRoot(root))
+}
+
+// DeployRoot returns the directory an app is deployed to. Zero-downtime
+// deployments (Deployer, Envoyer) run each release from
+// <deploy root>/releases/<release>; other roots are returned as they are.
+func DeployRoot(root string) string {
+	root = filepath.Clean(root)
+	if parent := filepath.Dir(root); filepath.Base(parent) == "releases" {
+		return filepath.Dir(parent)
+	}
+	return root
+}
+
+func projectNames(projects []Project) string {
+	names := make([]string, len(projects))
+	for i, project := range projects {
+		names[i] = project.Name
+	}
+	return strings.Join(names, ", ")
+}
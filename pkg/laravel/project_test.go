@@ -0,0 +1,233 @@
+package laravel
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestProjectsResolve(t *testing.T) {
+	dir := t.TempDir()
+	release := filepath.Join(dir, "releases", "42")
+	if err := os.MkdirAll(release, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	current := filepath.Join(dir, "current")
+	if err := os.Symlink(release, current); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	discovered := []Project{
+		{Name: ProjectName(release), Root: release, PHP: "php"}, // Worker of the configured shop project
+		{Name: "blog", Root: "/srv/blog", PHP: "php"},
+	}
+	projects := NewProjects([]Project{{Name: "shop", Root: current, PHP: "php"}}, func() ([]Project, error) {
+		return discovered, nil
+	})
+
+	list, err := projects.List()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "shop" || list[1].Name != "blog" || !list[1].Discovered {
+		t.Errorf("Expected configured shop and discovered blog, got %+v", list)
+	}
+
+	tests := []struct {
+		name    string
+		target  string
+		want    string
+		wantErr string
+	}{
+		{"by name", "shop", "shop", ""},
+		{"discovered by name", "blog", "blog", ""},
+		{"by resolved root", release, "shop", ""},
+		{"unknown", "api", "", "unknown project api"},
+		{"ambiguous without name", "", "", "several Laravel projects"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, err := projects.Resolve(tt.target)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if project.Name != tt.want {
+				t.Errorf("Expected project %s, got %s", tt.want, project.Name)
+			}
+		})
+	}
+
+	if name := projects.NameFor(release); name != "shop" {
+		t.Errorf("Expected worker root to map to shop, got %s", name)
+	}
+}
+
+func TestProjectsResolveSingle(t *testing.T) {
+	projects := NewProjects(nil, func() ([]Project, error) {
+		return []Project{{Name: "app", Root: "/var/www/app", PHP: "php"}}, nil
+	})
+
+	project, err := projects.Resolve("")
+	if err != nil || project.Root != "/var/www/app" {
+		t.Errorf("Expected the only project, got %+v (%v)", project, err)
+	}
+}
+
+func TestProjectsDetection(t *testing.T) {
+	calls := 0
+	projects := NewProjects([]Project{{Name: "shop", Root: "/var/www/shop"}}, nil).WithDetection(func(project Project) *Settings {
+		calls++
+		return &Settings{QueueConnection: project.Name}
+	})
+
+	for i := 0; i < 2; i++ {
+		project, err := projects.Resolve("shop")
+		if err != nil || project.Settings == nil || project.Settings.QueueConnection != "shop" {
+			t.Fatalf("Expected detected settings, got %+v (%v)", project, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected settings to be detected once, got %d calls", calls)
+	}
+	if configured := projects.Configured(); configured[0].Settings != nil {
+		t.Error("Expected configured projects to be left unchanged")
+	}
+}
+
+func TestProjectName(t *testing.T) {
+	dir := t.TempDir()
+	write := func(root, env string) string {
+		root = filepath.Join(dir, root)
+		if err := os.MkdirAll(root, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if env != "" {
+			if err := os.WriteFile(filepath.Join(root, ".env"), []byte(env), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return root
+	}
+
+	tests := []struct {
+		name string
+		root string
+		want string
+	}{
+		{"APP_NAME", write("srv/app", "APP_NAME=\"My Shop\"\n"), "my_shop"},
+		{"default APP_NAME", write("srv/blog", "APP_NAME=Laravel\n"), "blog"},
+		{"no .env", write("srv/api", ""), "api"},
+		{"release", write("home/forge/shop.test/releases/42", "APP_NAME=Laravel\n"), "shop.test"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ProjectName(tt.root); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProjectsUniqueNames(t *testing.T) {
+	discovered := []Project{
+		{Name: "shop", Root: "/srv/shop/releases/43"},
+		{Name: "shop", Root: "/srv/shop/releases/42"},
+		{Name: "shop", Root: "/srv/staging/releases/7"},
+		{Name: "api", Root: "/srv/api"},
+	}
+	projects := NewProjects([]Project{{Name: "api", Root: "/var/www/api"}}, func() ([]Project, error) {
+		return discovered, nil
+	})
+
+	list, err := projects.List()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var names []string
+	for _, project := range list {
+		names = append(names, project.Name)
+	}
+	expected := "api, api-2, shop, shop-43, shop-staging"
+	if got := strings.Join(names, ", "); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+
+	if name := projects.NameFor("/srv/shop/releases/43"); name != "shop-43" {
+		t.Errorf("Expected discovered root to keep its listed name, got %s", name)
+	}
+	if project, err := projects.Resolve("shop-staging"); err != nil || project.Root != "/srv/staging/releases/7" {
+		t.Errorf("Expected staging release, got %+v (%v)", project, err)
+	}
+}
+
+func TestProjectsDetectionRetriesFailures(t *testing.T) {
+	defer func(retry time.Duration) { detectRetry = retry }(detectRetry)
+	detectRetry = time.Hour
+
+	calls := 0
+	var fail atomic.Bool
+	fail.Store(true)
+	projects := NewProjects([]Project{{Name: "shop", Root: "/var/www/shop"}}, nil).WithDetection(func(project Project) *Settings {
+		calls++
+		if fail.Load() {
+			return nil
+		}
+		return &Settings{QueueConnection: "redis"}
+	})
+
+	for i := 0; i < 2; i++ {
+		if project, _ := projects.Resolve("shop"); project.Settings != nil {
+			t.Fatalf("Expected no settings, got %+v", project.Settings)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected a failed detection not to be retried right away, got %d calls", calls)
+	}
+
+	detectRetry = 0
+	fail.Store(false)
+	if project, _ := projects.Resolve("shop"); project.Settings == nil {
+		t.Error("Expected a failed detection to be retried")
+	}
+}
+
+func TestProjectsDetectionRunsOnce(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	projects := NewProjects([]Project{{Name: "shop", Root: "/var/www/shop"}}, nil).WithDetection(func(project Project) *Settings {
+		calls.Add(1)
+		<-release
+		return &Settings{QueueConnection: "redis"}
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if project, err := projects.Resolve("shop"); err != nil || project.Settings == nil {
+				t.Errorf("Expected detected settings, got %+v (%v)", project, err)
+			}
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected concurrent lookups to share one detection, got %d", n)
+	}
+}
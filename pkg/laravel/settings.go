@@ -0,0 +1,562 @@
+package laravel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Settings are the parts of a project's configuration the agent needs to
+// find its queues: Redis connections and key prefixes, queue names and the
+// failed job provider
+type Settings struct {
+	Source          string        `json:"source"` // "config" (resolved by PHP) or "env" (.env with framework defaults)
+	QueueConnection string        `json:"queueConnection"`
+	QueueDriver     string        `json:"queueDriver"`
+	Queues          []string      `json:"queues"`
+	Redis           RedisSettings `json:"redis"` // Connection used by the queue
+	// Redis queue connections by queue connection name, for jobs that
+	// were pushed to another connection than the default
+	RedisConnections map[string]RedisSettings `json:"redisConnections,omitempty"`
+	CacheDriver      string                   `json:"cacheDriver"`
+	CacheRedis       RedisSettings            `json:"cacheRedis"` // Connection used by the cache store (pause flags)
+	CachePrefix      string                   `json:"cachePrefix"`
+	HorizonPrefix    string                   `json:"horizonPrefix,omitempty"` // Empty without Horizon
+	FailedDriver     string                   `json:"failedDriver"`
+	FailedTable      string                   `json:"failedTable"`
+	FailedDSN        string                   `json:"-"` // mysql:// or postgres:// URL of the failed jobs database, if supported
+}
+
+// RedisSettings describe a connection from config/database.php.
+// The agent keeps using its own Redis address; only the database and key
+// prefix are taken from the project.
+type RedisSettings struct {
+	Connection string `json:"connection"`
+	Host       string `json:"host"`
+	Port       int    `json:"port"`
+	DB         int    `json:"db"`
+	Prefix     string `json:"prefix"`
+}
+
+// KeyPrefix returns the Redis key prefix of queue keys ("" for nil settings)
+func (s *Settings) KeyPrefix() string {
+	if s == nil {
+		return ""
+	}
+	return s.Redis.Prefix
+}
+
+// QueueRedis returns the Redis connection behind a queue connection
+// ("" = the default one). It fails for connections that are unknown or
+// don't use Redis, rather than guessing.
+func (s *Settings) QueueRedis(connection string) (RedisSettings, error) {
+	if s == nil {
+		return RedisSettings{}, fmt.Errorf("the project's queue connections are unknown")
+	}
+	if connection == "" {
+		connection = s.QueueConnection
+	}
+	if conn, ok := s.RedisConnections[connection]; ok {
+		return conn, nil
+	}
+	// An explicitly configured default connection may not be in the map
+	if connection == s.QueueConnection && s.QueueDriver == "redis" {
+		return s.Redis, nil
+	}
+	return RedisSettings{}, fmt.Errorf("queue connection %q is not a known Redis connection", connection)
+}
+
+// QueuePrefix returns the prefix of queue keys as used by the queue probes
+func (s *Settings) QueuePrefix() string {
+	return s.KeyPrefix() + "queues"
+}
+
+// PauseCachePrefix returns the prefix of pause flags: the Redis prefix of
+// the cache connection followed by the cache prefix
+func (s *Settings) PauseCachePrefix() string {
+	return s.CacheRedis.Prefix + s.CachePrefix
+}
+
+// RedisClient returns a client on the given database, sharing base's
+// address and credentials. It returns base itself when the database matches;
+// otherwise the caller must close the new client.
+func RedisClient(base *redis.Client, db int) *redis.Client {
+	opts := *base.Options()
+	if opts.DB == db {
+		return base
+	}
+	opts.DB = db
+	return redis.NewClient(&opts)
+}
+
+// EnvSettings derives settings from the project's .env file, applying the
+// defaults of Laravel's config files for unset variables. It can't see
+// values changed in the config files themselves; see ResolveSettings.
+func EnvSettings(root string) (*Settings, error) {
+	env, err := LoadEnv(root)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = os.Stat(filepath.Join(root, "config", "horizon.php"))
+	settings := configFromEnv(env, err == nil).settings()
+	settings.Source = "env"
+	return settings, nil
+}
+
+// resolveScript boots the application and prints the configuration the
+// settings are built from, after a marker that separates it from any
+// output of service providers
+const resolveScript = `require 'vendor/autoload.php';
+$app = require 'bootstrap/app.php';
+$app->make(Illuminate\Contracts\Console\Kernel::class)->bootstrap();
+$c = $app->make('config');
+echo "\n` + resolveMarker + `", json_encode([
+	'env' => $app->environment(),
+	'queue' => $c->get('queue'),
+	'redis' => $c->get('database.redis'),
+	'database' => ['default' => $c->get('database.default'), 'connections' => $c->get('database.connections')],
+	'cache' => $c->get('cache'),
+	'horizon' => $c->get('horizon'),
+]);`
+
+const resolveMarker = "@@quasar-config@@"
+
+// ResolveSettings boots the application with PHP and reads its resolved
+// configuration, including config/*.php changes and the config cache.
+// This runs the application's service providers.
+func ResolveSettings(ctx context.Context, root, php string) (*Settings, error) {
+	if php == "" {
+		php = "php"
+	}
+
+	cmd := exec.CommandContext(ctx, php, "-r", resolveScript)
+	cmd.Dir = root
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	_, data, ok := bytes.Cut(out, []byte(resolveMarker))
+	if !ok {
+		return nil, fmt.Errorf("no configuration in output")
+	}
+	var c resolvedConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	settings := c.settings()
+	settings.Source = "config"
+	return settings, nil
+}
+
+// resolvedConfig mirrors the configuration printed by resolveScript
+type resolvedConfig struct {
+	Env   string `json:"env"`
+	Queue struct {
+		Default     string                     `json:"default"`
+		Connections map[string]queueConnection `json:"connections"`
+		Failed      struct {
+			Driver   string `json:"driver"`
+			Database string `json:"database"`
+			Table    string `json:"table"`
+		} `json:"failed"`
+	} `json:"queue"`
+	Redis    redisConfig `json:"redis"`
+	Database struct {
+		Default     string                        `json:"default"`
+		Connections map[string]databaseConnection `json:"connections"`
+	} `json:"database"`
+	Cache struct {
+		Default string                `json:"default"`
+		Stores  map[string]cacheStore `json:"stores"`
+		Prefix  string                `json:"prefix"`
+	} `json:"cache"`
+	Horizon *horizonConfig `json:"horizon"` // nil without Horizon
+}
+
+type horizonConfig struct {
+	Prefix string `json:"prefix"`
+	// Raw because PHP encodes empty arrays as lists
+	Defaults     json.RawMessage `json:"defaults"`
+	Environments json.RawMessage `json:"environments"`
+}
+
+type queueConnection struct {
+	Driver     string `json:"driver"`
+	Connection string `json:"connection"` // Redis connection name
+	Queue      string `json:"queue"`
+}
+
+type cacheStore struct {
+	Driver     string `json:"driver"`
+	Connection string `json:"connection"`
+}
+
+type databaseConnection struct {
+	Driver   string  `json:"driver"`
+	URL      string  `json:"url"`
+	Host     string  `json:"host"`
+	Port     flexInt `json:"port"`
+	Database string  `json:"database"`
+	Username string  `json:"username"`
+	Password string  `json:"password"`
+}
+
+type redisConnection struct {
+	URL      string       `json:"url"`
+	Host     string       `json:"host"`
+	Port     flexInt      `json:"port"`
+	Database flexInt      `json:"database"`
+	Options  redisOptions `json:"options"`
+}
+
+type redisOptions struct {
+	Prefix *string `json:"prefix"`
+}
+
+// redisConfig is database.redis: global options next to named connections
+type redisConfig struct {
+	Options     redisOptions
+	Connections map[string]redisConnection
+}
+
+func (r *redisConfig) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	r.Connections = make(map[string]redisConnection)
+	for name, value := range raw {
+		switch name {
+		case "client", "clusters":
+		case "options":
+			_ = json.Unmarshal(value, &r.Options)
+		default:
+			var conn redisConnection
+			if json.Unmarshal(value, &conn) == nil {
+				r.Connections[name] = conn
+			}
+		}
+	}
+	return nil
+}
+
+type horizonSupervisor struct {
+	Connection string      `json:"connection"`
+	Queue      flexStrings `json:"queue"`
+}
+
+// flexInt accepts numbers and numeric strings (values read with env() are strings)
+type flexInt int
+
+func (n *flexInt) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*n = 0
+		return nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	*n = flexInt(v)
+	return nil
+}
+
+// flexStrings accepts a list or a comma-separated string
+type flexStrings []string
+
+func (s *flexStrings) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*s = list
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*s = strings.Split(value, ",")
+	return nil
+}
+
+// settings extracts the agent's settings from the configuration
+func (c *resolvedConfig) settings() *Settings {
+	s := &Settings{
+		QueueConnection: c.Queue.Default,
+		CachePrefix:     c.Cache.Prefix,
+		FailedDriver:    c.Queue.Failed.Driver,
+		FailedTable:     c.Queue.Failed.Table,
+	}
+
+	conn := c.Queue.Connections[c.Queue.Default]
+	s.QueueDriver = conn.Driver
+	if conn.Driver == "redis" {
+		s.Redis = c.redisSettings(orDefault(conn.Connection, "default"))
+	}
+
+	for name, qc := range c.Queue.Connections {
+		if qc.Driver != "redis" {
+			continue
+		}
+		if s.RedisConnections == nil {
+			s.RedisConnections = make(map[string]RedisSettings)
+		}
+		s.RedisConnections[name] = c.redisSettings(orDefault(qc.Connection, "default"))
+	}
+
+	queues := []string{orDefault(conn.Queue, "default")}
+	if c.Horizon != nil {
+		s.HorizonPrefix = c.Horizon.Prefix
+		queues = append(queues, c.horizonQueues()...)
+	}
+	seen := make(map[string]bool)
+	for _, q := range queues {
+		if q = strings.TrimSpace(q); q != "" && !seen[q] {
+			seen[q] = true
+			s.Queues = append(s.Queues, q)
+		}
+	}
+
+	store := c.Cache.Stores[c.Cache.Default]
+	s.CacheDriver = store.Driver
+	if store.Driver == "redis" {
+		s.CacheRedis = c.redisSettings(orDefault(store.Connection, "default"))
+	}
+
+	if s.FailedTable == "" {
+		s.FailedTable = "failed_jobs"
+	}
+	if db, ok := c.Database.Connections[orDefault(c.Queue.Failed.Database, c.Database.Default)]; ok {
+		s.FailedDSN = db.dsn()
+	}
+	return s
+}
+
+// horizonQueues returns the queues Horizon supervisors of the current
+// environment process on the default queue connection
+func (c *resolvedConfig) horizonQueues() []string {
+	supervisors := make(map[string]horizonSupervisor)
+	_ = json.Unmarshal(c.Horizon.Defaults, &supervisors)
+
+	var environments map[string]map[string]horizonSupervisor
+	_ = json.Unmarshal(c.Horizon.Environments, &environments)
+	for _, env := range []string{"*", c.Env} {
+		for name, sup := range environments[env] {
+			merged := supervisors[name]
+			if sup.Connection != "" {
+				merged.Connection = sup.Connection
+			}
+			if len(sup.Queue) > 0 {
+				merged.Queue = sup.Queue
+			}
+			supervisors[name] = merged
+		}
+	}
+
+	var queues []string
+	for _, sup := range supervisors {
+		if sup.Connection == "" || sup.Connection == c.Queue.Default {
+			queues = append(queues, sup.Queue...)
+		}
+	}
+	sort.Strings(queues)
+	return queues
+}
+
+// redisSettings resolves a Redis connection like Laravel's RedisManager:
+// the url wins over host/port/database and connection options over global ones
+func (c *resolvedConfig) redisSettings(name string) RedisSettings {
+	conn := c.Redis.Connections[name]
+	r := RedisSettings{
+		Connection: name,
+		Host:       conn.Host,
+		Port:       int(conn.Port),
+		DB:         int(conn.Database),
+	}
+
+	if conn.URL != "" {
+		if u, err := url.Parse(conn.URL); err == nil && u.Host != "" {
+			r.Host = u.Hostname()
+			if port, err := strconv.Atoi(u.Port()); err == nil {
+				r.Port = port
+			}
+			if db, err := strconv.Atoi(strings.TrimPrefix(u.Path, "/")); err == nil {
+				r.DB = db
+			}
+		}
+	}
+
+	if conn.Options.Prefix != nil {
+		r.Prefix = *conn.Options.Prefix
+	} else if c.Redis.Options.Prefix != nil {
+		r.Prefix = *c.Redis.Options.Prefix
+	}
+	return r
+}
+
+// dsn returns the connection as a URL for NewDatabaseFailedStore
+// ("" for drivers it doesn't support)
+func (d databaseConnection) dsn() string {
+	var scheme, port string
+	switch d.Driver {
+	case "mysql", "mariadb":
+		scheme, port = "mysql", "3306"
+	case "pgsql":
+		scheme, port = "postgres", "5432"
+	default:
+		return ""
+	}
+
+	if d.URL != "" {
+		if u, err := url.Parse(d.URL); err == nil && u.Host != "" {
+			u.Scheme = scheme
+			return u.String()
+		}
+	}
+
+	if d.Port > 0 {
+		port = strconv.Itoa(int(d.Port))
+	}
+	u := url.URL{
+		Scheme: scheme,
+		User:   url.UserPassword(d.Username, d.Password),
+		Host:   net.JoinHostPort(orDefault(d.Host, "127.0.0.1"), port),
+		Path:   "/" + d.Database,
+	}
+	return u.String()
+}
+
+// configFromEnv builds the configuration Laravel's default config files
+// produce for the given environment
+func configFromEnv(env map[string]string, horizon bool) *resolvedConfig {
+	get := func(key, def string) string {
+		v, ok := env[key]
+		if !ok {
+			return def
+		}
+		// env() returns null for these, and null settings are empty strings here
+		if v == "null" || v == "(null)" {
+			return ""
+		}
+		return v
+	}
+	getInt := func(key string, def int) flexInt {
+		if v, err := strconv.Atoi(get(key, "")); err == nil {
+			return flexInt(v)
+		}
+		return flexInt(def)
+	}
+	slug := Slug(get("APP_NAME", "Laravel"))
+
+	c := &resolvedConfig{Env: get("APP_ENV", "production")}
+
+	c.Queue.Default = get("QUEUE_CONNECTION", "database")
+	c.Queue.Connections = map[string]queueConnection{
+		"sync":       {Driver: "sync"},
+		"database":   {Driver: "database", Queue: get("DB_QUEUE", "default")},
+		"beanstalkd": {Driver: "beanstalkd", Queue: get("BEANSTALKD_QUEUE", "default")},
+		"sqs":        {Driver: "sqs", Queue: get("SQS_QUEUE", "default")},
+		"redis": {
+			Driver:     "redis",
+			Connection: get("REDIS_QUEUE_CONNECTION", "default"),
+			Queue:      get("REDIS_QUEUE", "default"),
+		},
+	}
+	c.Queue.Failed.Driver = get("QUEUE_FAILED_DRIVER", "database-uuids")
+	c.Queue.Failed.Database = get("DB_CONNECTION", "sqlite")
+	c.Queue.Failed.Table = "failed_jobs"
+
+	prefix := get("REDIS_PREFIX", slug+"_database_")
+	c.Redis.Options.Prefix = &prefix
+	c.Redis.Connections = map[string]redisConnection{
+		"default": {
+			URL:      get("REDIS_URL", ""),
+			Host:     get("REDIS_HOST", "127.0.0.1"),
+			Port:     getInt("REDIS_PORT", 6379),
+			Database: getInt("REDIS_DB", 0),
+		},
+		"cache": {
+			URL:      get("REDIS_URL", ""),
+			Host:     get("REDIS_HOST", "127.0.0.1"),
+			Port:     getInt("REDIS_PORT", 6379),
+			Database: getInt("REDIS_CACHE_DB", 1),
+		},
+	}
+
+	c.Database.Default = get("DB_CONNECTION", "sqlite")
+	c.Database.Connections = make(map[string]databaseConnection)
+	for _, driver := range []string{"mysql", "mariadb", "pgsql"} {
+		c.Database.Connections[driver] = databaseConnection{
+			Driver:   driver,
+			URL:      get("DB_URL", get("DATABASE_URL", "")),
+			Host:     get("DB_HOST", "127.0.0.1"),
+			Port:     getInt("DB_PORT", 0),
+			Database: get("DB_DATABASE", "laravel"),
+			Username: get("DB_USERNAME", "root"),
+			Password: get("DB_PASSWORD", ""),
+		}
+	}
+
+	// Laravel 11 reads CACHE_STORE, earlier versions CACHE_DRIVER
+	c.Cache.Default = get("CACHE_STORE", get("CACHE_DRIVER", "database"))
+	c.Cache.Stores = map[string]cacheStore{
+		"redis": {Driver: "redis", Connection: get("REDIS_CACHE_CONNECTION", "cache")},
+	}
+	for _, driver := range []string{"array", "database", "file", "memcached", "dynamodb", "octane"} {
+		c.Cache.Stores[driver] = cacheStore{Driver: driver}
+	}
+	c.Cache.Prefix = get("CACHE_PREFIX", slug+"_cache_")
+
+	if horizon {
+		c.Horizon = &horizonConfig{Prefix: get("HORIZON_PREFIX", slug+"_horizon:")}
+	}
+	return c
+}
+
+// Slug converts a title like Str::slug($title, '_'), which Laravel's config
+// files use to derive default prefixes from APP_NAME
+func Slug(title string) string {
+	title = strings.ReplaceAll(title, "-", "_")
+	title = strings.ReplaceAll(title, "@", "_at_")
+
+	var b strings.Builder
+	separator := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if separator && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			separator = false
+			b.WriteRune(r)
+		case r == '_' || unicode.IsSpace(r):
+			separator = true
+		}
+	}
+	return b.String()
+}
+
+func orDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
@@ -0,0 +1,333 @@
+package laravel
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Array is an ordered PHP array. Keys are int64 or string.
+type Array struct {
+	Entries []ArrayEntry
+}
+
+// ArrayEntry is one key/value pair of a PHP array
+type ArrayEntry struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// Get returns the value stored under a string or integer key
+func (a *Array) Get(key interface{}) (interface{}, bool) {
+	if n, ok := key.(int); ok {
+		key = int64(n)
+	}
+	for _, e := range a.Entries {
+		if e.Key == key {
+			return e.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Visibility of a serialized object property
+type Visibility string
+
+const (
+	Public    Visibility = "public"
+	Protected Visibility = "protected"
+	Private   Visibility = "private"
+)
+
+// Object is a serialized PHP object (O:)
+type Object struct {
+	Class      string
+	Properties []Property
+}
+
+// Property is one serialized object property
+type Property struct {
+	Name       string
+	Visibility Visibility
+	Class      string // Declaring class of a private property
+	Value      interface{}
+}
+
+// Get returns the value of a property by name, regardless of visibility
+func (o *Object) Get(name string) (interface{}, bool) {
+	for _, p := range o.Properties {
+		if p.Name == name {
+			return p.Value, true
+		}
+	}
+	return nil, false
+}
+
+// CustomObject is an object implementing Serializable (C:); its data is opaque
+type CustomObject struct {
+	Class string
+	Data  string
+}
+
+// Enum is a PHP 8.1 enum case (E:)
+type Enum struct {
+	Class string
+	Case  string
+}
+
+// Reference points back at an earlier value (r: for objects, R: for PHP references)
+type Reference struct {
+	Index int
+	ByRef bool
+}
+
+// Unserialize parses a PHP serialize() string into Go values:
+// nil, bool, int64, float64, string, *Array, *Object, *CustomObject, Enum or Reference.
+func Unserialize(s string) (interface{}, error) {
+	d := &decoder{s: s}
+	v, err := d.value()
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.s) {
+		return nil, d.errorf("unexpected trailing data")
+	}
+	return v, nil
+}
+
+type decoder struct {
+	s   string
+	pos int
+}
+
+func (d *decoder) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("unserialize at offset %d: %s", d.pos, fmt.Sprintf(format, args...))
+}
+
+func (d *decoder) expect(c byte) error {
+	if d.pos >= len(d.s) || d.s[d.pos] != c {
+		return d.errorf("expected %q", c)
+	}
+	d.pos++
+	return nil
+}
+
+// until returns the text up to the delimiter and skips past it
+func (d *decoder) until(delim byte) (string, error) {
+	i := strings.IndexByte(d.s[d.pos:], delim)
+	if i < 0 {
+		return "", d.errorf("expected %q", delim)
+	}
+	text := d.s[d.pos : d.pos+i]
+	d.pos += i + 1
+	return text, nil
+}
+
+func (d *decoder) int(delim byte) (int64, error) {
+	text, err := d.until(delim)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return 0, d.errorf("invalid integer %q", text)
+	}
+	return n, nil
+}
+
+// quoted reads `<len>:"<bytes>"` where len is a byte count
+func (d *decoder) quoted() (string, error) {
+	n, err := d.int(':')
+	if err != nil {
+		return "", err
+	}
+	if err := d.expect('"'); err != nil {
+		return "", err
+	}
+	if n < 0 || d.pos+int(n) > len(d.s) {
+		return "", d.errorf("string length %d out of range", n)
+	}
+	text := d.s[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	if err := d.expect('"'); err != nil {
+		return "", err
+	}
+	return text, nil
+}
+
+func (d *decoder) value() (interface{}, error) {
+	if d.pos+1 >= len(d.s) {
+		return nil, d.errorf("unexpected end of input")
+	}
+
+	kind := d.s[d.pos]
+	if kind == 'N' {
+		d.pos++
+		return nil, d.expect(';')
+	}
+
+	d.pos++
+	if err := d.expect(':'); err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case 'b':
+		n, err := d.int(';')
+		return n != 0, err
+	case 'i':
+		return d.int(';')
+	case 'd':
+		text, err := d.until(';')
+		if err != nil {
+			return nil, err
+		}
+		switch text {
+		case "INF":
+			return math.Inf(1), nil
+		case "-INF":
+			return math.Inf(-1), nil
+		case "NAN":
+			return math.NaN(), nil
+		}
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, d.errorf("invalid float %q", text)
+		}
+		return f, nil
+	case 's':
+		text, err := d.quoted()
+		if err != nil {
+			return nil, err
+		}
+		return text, d.expect(';')
+	case 'a':
+		return d.array()
+	case 'O':
+		return d.object()
+	case 'C':
+		class, err := d.quoted()
+		if err != nil {
+			return nil, err
+		}
+		if err := d.expect(':'); err != nil {
+			return nil, err
+		}
+		n, err := d.int(':')
+		if err != nil {
+			return nil, err
+		}
+		if err := d.expect('{'); err != nil {
+			return nil, err
+		}
+		if n < 0 || d.pos+int(n) > len(d.s) {
+			return nil, d.errorf("data length %d out of range", n)
+		}
+		data := d.s[d.pos : d.pos+int(n)]
+		d.pos += int(n)
+		return &CustomObject{Class: class, Data: data}, d.expect('}')
+	case 'E':
+		text, err := d.quoted()
+		if err != nil {
+			return nil, err
+		}
+		class, name, ok := strings.Cut(text, ":")
+		if !ok {
+			return nil, d.errorf("invalid enum %q", text)
+		}
+		return Enum{Class: class, Case: name}, d.expect(';')
+	case 'r', 'R':
+		n, err := d.int(';')
+		return Reference{Index: int(n), ByRef: kind == 'R'}, err
+	}
+
+	d.pos -= 2
+	return nil, d.errorf("unknown type %q", kind)
+}
+
+func (d *decoder) array() (*Array, error) {
+	n, err := d.int(':')
+	if err != nil {
+		return nil, err
+	}
+	if err := d.expect('{'); err != nil {
+		return nil, err
+	}
+
+	arr := &Array{}
+	for i := int64(0); i < n; i++ {
+		key, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		switch key.(type) {
+		case int64, string:
+		default:
+			return nil, d.errorf("invalid array key %v", key)
+		}
+
+		value, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		arr.Entries = append(arr.Entries, ArrayEntry{Key: key, Value: value})
+	}
+
+	return arr, d.expect('}')
+}
+
+func (d *decoder) object() (*Object, error) {
+	class, err := d.quoted()
+	if err != nil {
+		return nil, err
+	}
+	if err := d.expect(':'); err != nil {
+		return nil, err
+	}
+	n, err := d.int(':')
+	if err != nil {
+		return nil, err
+	}
+	if err := d.expect('{'); err != nil {
+		return nil, err
+	}
+
+	obj := &Object{Class: class}
+	for i := int64(0); i < n; i++ {
+		key, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		name, ok := key.(string)
+		if !ok {
+			// Integer property names come from casting arrays to objects
+			name = fmt.Sprint(key)
+		}
+
+		value, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		obj.Properties = append(obj.Properties, propertyFromKey(name, value))
+	}
+
+	return obj, d.expect('}')
+}
+
+// propertyFromKey decodes the mangled property name: "\0*\0name" is protected,
+// "\0Class\0name" is private to Class
+func propertyFromKey(key string, value interface{}) Property {
+	if !strings.HasPrefix(key, "\x00") {
+		return Property{Name: key, Visibility: Public, Value: value}
+	}
+
+	class, name, ok := strings.Cut(key[1:], "\x00")
+	if !ok {
+		return Property{Name: key, Visibility: Public, Value: value}
+	}
+	if class == "*" {
+		return Property{Name: name, Visibility: Protected, Value: value}
+	}
+	return Property{Name: name, Visibility: Private, Class: class, Value: value}
+}
@@ -0,0 +1,95 @@
+package laravel
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUnserializeScalars(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"N;", nil},
+		{"b:1;", true},
+		{"b:0;", false},
+		{"i:-42;", int64(-42)},
+		{"d:0.5;", 0.5},
+		{"d:INF;", math.Inf(1)},
+		{`s:6:"héllo";`, "héllo"},
+		{`s:3:"a"b";`, `a"b`},
+		{`E:21:"App\Enums\Status:Paid";`, Enum{Class: `App\Enums\Status`, Case: "Paid"}},
+		{"r:3;", Reference{Index: 3}},
+		{"R:2;", Reference{Index: 2, ByRef: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Unserialize(tt.input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %#v, got %#v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestUnserializeCompound(t *testing.T) {
+	input := `a:3:{i:0;s:1:"x";s:4:"user";O:8:"App\User":3:{s:4:"name";s:3:"Ada";s:8:"` + "\x00*\x00token" + `";N;s:16:"` + "\x00App\\User\x00secret" + `";i:7;}i:5;C:11:"ArrayObject":4:{x:i0}}`
+
+	v, err := Unserialize(input)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	arr, ok := v.(*Array)
+	if !ok || len(arr.Entries) != 3 {
+		t.Fatalf("Expected array with 3 entries, got %#v", v)
+	}
+
+	if first, _ := arr.Get(0); first != "x" {
+		t.Errorf("Expected [0] = x, got %v", first)
+	}
+
+	user, _ := arr.Get("user")
+	obj, ok := user.(*Object)
+	if !ok || obj.Class != `App\User` {
+		t.Fatalf("Expected App\\User object, got %#v", user)
+	}
+
+	expected := []Property{
+		{Name: "name", Visibility: Public, Value: "Ada"},
+		{Name: "token", Visibility: Protected},
+		{Name: "secret", Visibility: Private, Class: `App\User`, Value: int64(7)},
+	}
+	for i, prop := range expected {
+		if obj.Properties[i] != prop {
+			t.Errorf("Expected property %+v, got %+v", prop, obj.Properties[i])
+		}
+	}
+
+	custom, _ := arr.Get(5)
+	if c, ok := custom.(*CustomObject); !ok || c.Class != "ArrayObject" || c.Data != "x:i0" {
+		t.Errorf("Expected ArrayObject custom object, got %#v", custom)
+	}
+}
+
+func TestUnserializeErrors(t *testing.T) {
+	inputs := []string{
+		"",
+		"i:12",
+		`s:10:"short";`,
+		"a:2:{i:0;i:1;}",
+		"a:1:{d:0.5;i:1;}",
+		"x:1;",
+		"i:1;i:2;",
+	}
+
+	for _, input := range inputs {
+		if _, err := Unserialize(input); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"path"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/types"
+)
+
+// DefaultTemplate renders a one-line summary of an event
+const DefaultTemplate = "[{{.Severity}}] {{.Service}} on {{.Node}}: {{.Message}}"
+
+// Options configures delivery behaviour
+type Options struct {
+	Template  string        // text/template rendered into Message.Text (default: DefaultTemplate)
+	Events    []string      // Event type patterns to deliver (path.Match syntax, empty = all)
+	Retries   int           // Extra attempts per notifier after a failure
+	Backoff   time.Duration // Delay before the first retry, doubled on each attempt
+	RateLimit int           // Max messages per minute (0 = unlimited)
+	Timeout   time.Duration // Per-attempt timeout
+}
+
+// templateData is what templates can reference
+type templateData struct {
+	Type     string
+	Severity types.EventSeverity
+	Message  string
+	Data     map[string]interface{}
+	Service  string
+	Node     string
+	Hostname string
+	Time     time.Time
+}
+
+// Dispatcher filters, renders and delivers events to notifiers in the background
+type Dispatcher struct {
+	logger    *slog.Logger
+	notifiers []Notifier
+	opts      Options
+	tmpl      *template.Template
+
+	queue    chan Message
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+
+	// Sliding one-minute window of sent messages
+	sent       []time.Time
+	suppressed int
+}
+
+// NewDispatcher creates a dispatcher for the given notifiers
+func NewDispatcher(logger *slog.Logger, opts Options, notifiers ...Notifier) (*Dispatcher, error) {
+	if opts.Template == "" {
+		opts.Template = DefaultTemplate
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+
+	tmpl, err := template.New("notify").Option("missingkey=zero").Parse(opts.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Dispatcher{
+		logger:    logger,
+		notifiers: notifiers,
+		opts:      opts,
+		tmpl:      tmpl,
+		queue:     make(chan Message, 256),
+		stopChan:  make(chan struct{}),
+	}, nil
+}
+
+// Start begins delivering queued messages
+func (d *Dispatcher) Start() {
+	d.wg.Add(1)
+	go d.loop()
+}
+
+// Stop stops delivery and waits for the in-flight message (if any)
+func (d *Dispatcher) Stop() {
+	close(d.stopChan)
+	d.wg.Wait()
+}
+
+// Notify queues matching events for delivery. It never blocks: when the
+// queue is full the event is dropped and logged.
+func (d *Dispatcher) Notify(service, node, hostname string, events []types.Event) {
+	for _, event := range events {
+		if !d.matches(event.Type) {
+			continue
+		}
+
+		msg := Message{
+			Service:  service,
+			Node:     node,
+			Hostname: hostname,
+			Event:    event,
+		}
+		msg.Text = d.render(msg)
+
+		select {
+		case d.queue <- msg:
+		default:
+			d.logger.Warn("Notification queue full, dropping event", "type", event.Type)
+		}
+	}
+}
+
+func (d *Dispatcher) matches(eventType string) bool {
+	if len(d.opts.Events) == 0 {
+		return true
+	}
+	for _, pattern := range d.opts.Events {
+		if ok, _ := path.Match(pattern, eventType); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Dispatcher) render(msg Message) string {
+	var buf bytes.Buffer
+	err := d.tmpl.Execute(&buf, templateData{
+		Type:     msg.Event.Type,
+		Severity: msg.Event.Severity,
+		Message:  msg.Event.Message,
+		Data:     msg.Event.Data,
+		Service:  msg.Service,
+		Node:     msg.Node,
+		Hostname: msg.Hostname,
+		Time:     time.UnixMilli(msg.Event.Timestamp),
+	})
+	if err != nil {
+		d.logger.Warn("Failed to render notification template", "error", err)
+		return msg.Event.Message
+	}
+	return buf.String()
+}
+
+func (d *Dispatcher) loop() {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case <-d.stopChan:
+			return
+		case msg := <-d.queue:
+			if !d.allow(time.Now()) {
+				d.logger.Warn("Notification rate limit reached, dropping event", "type", msg.Event.Type)
+				continue
+			}
+			for _, n := range d.notifiers {
+				d.deliver(n, msg)
+			}
+		}
+	}
+}
+
+// allow reports whether another message fits in the rate limit
+func (d *Dispatcher) allow(now time.Time) bool {
+	if d.opts.RateLimit <= 0 {
+		return true
+	}
+
+	cutoff := now.Add(-time.Minute)
+	kept := d.sent[:0]
+	for _, t := range d.sent {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	d.sent = kept
+
+	if len(d.sent) >= d.opts.RateLimit {
+		d.suppressed++
+		return false
+	}
+	if d.suppressed > 0 {
+		d.logger.Warn("Notifications were suppressed by the rate limit", "count", d.suppressed)
+		d.suppressed = 0
+	}
+	d.sent = append(d.sent, now)
+	return true
+}
+
+// deliver sends a message with exponential backoff between attempts
+func (d *Dispatcher) deliver(n Notifier, msg Message) {
+	backoff := d.opts.Backoff
+
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), d.opts.Timeout)
+		err := n.Send(ctx, msg)
+		cancel()
+		if err == nil {
+			return
+		}
+
+		if attempt >= d.opts.Retries {
+			d.logger.Error("Notification failed", "notifier", n.Name(), "type", msg.Event.Type, "attempts", attempt+1, "error", err)
+			return
+		}
+		d.logger.Warn("Notification failed, retrying", "notifier", n.Name(), "type", msg.Event.Type, "error", err, "backoff", backoff)
+
+		select {
+		case <-d.stopChan:
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
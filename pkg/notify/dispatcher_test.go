@@ -0,0 +1,140 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/types"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// receiver is a local HTTP endpoint that fails the first `failures` requests
+func receiver(t *testing.T, failures int32) (*httptest.Server, <-chan []byte, *int32) {
+	bodies := make(chan []byte, 16)
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if n <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies <- body
+	}))
+	t.Cleanup(server.Close)
+
+	return server, bodies, &calls
+}
+
+func wait(t *testing.T, bodies <-chan []byte) []byte {
+	t.Helper()
+	select {
+	case body := <-bodies:
+		return body
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a notification, got none")
+		return nil
+	}
+}
+
+func TestWebhookDeliveryWithRetries(t *testing.T) {
+	server, bodies, calls := receiver(t, 2)
+
+	d, err := NewDispatcher(testLogger(), Options{Retries: 3, Backoff: time.Millisecond}, NewWebhookNotifier(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Start()
+	defer d.Stop()
+
+	event := types.NewEvent("alert.firing", types.SeverityCritical, "Alert backlog firing", map[string]interface{}{"rule": "backlog"})
+	d.Notify("shop", "web-1-42", "web-1", []types.Event{event})
+
+	var msg Message
+	if err := json.Unmarshal(wait(t, bodies), &msg); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Errorf("Expected 3 attempts, got %d", got)
+	}
+	if msg.Event.Type != "alert.firing" || msg.Node != "web-1-42" {
+		t.Errorf("Expected alert.firing from web-1-42, got %+v", msg)
+	}
+	if msg.Text != "[critical] shop on web-1-42: Alert backlog firing" {
+		t.Errorf("Expected default template text, got %q", msg.Text)
+	}
+}
+
+func TestSlackTemplateAndFilter(t *testing.T) {
+	server, bodies, _ := receiver(t, 0)
+
+	d, err := NewDispatcher(testLogger(), Options{
+		Template: `{{.Type}} {{index .Data "rule"}}`,
+		Events:   []string{"alert.*"},
+	}, NewSlackNotifier(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Start()
+	defer d.Stop()
+
+	d.Notify("shop", "node", "host", []types.Event{
+		types.NewEvent("worker.started", types.SeverityInfo, "started", nil),
+		types.NewEvent("alert.resolved", types.SeverityInfo, "resolved", map[string]interface{}{"rule": "cpu"}),
+	})
+
+	var body map[string]string
+	if err := json.Unmarshal(wait(t, bodies), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["text"] != "alert.resolved cpu" {
+		t.Errorf("Expected rendered text, got %q", body["text"])
+	}
+
+	select {
+	case extra := <-bodies:
+		t.Errorf("Expected filtered event to be skipped, got %s", extra)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	d, err := NewDispatcher(testLogger(), Options{RateLimit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		at       time.Time
+		expected bool
+	}{
+		{now, true},
+		{now.Add(time.Second), true},
+		{now.Add(2 * time.Second), false},
+		{now.Add(61 * time.Second), true},
+	}
+
+	for i, tt := range tests {
+		if got := d.allow(tt.at); got != tt.expected {
+			t.Errorf("call %d: Expected %v, got %v", i, tt.expected, got)
+		}
+	}
+}
+
+func TestInvalidTemplate(t *testing.T) {
+	if _, err := NewDispatcher(testLogger(), Options{Template: "{{.Type"}); err == nil {
+		t.Error("Expected template parse error")
+	}
+}
@@ -0,0 +1,152 @@
+// Package notify delivers agent events directly to webhooks, chat and local
+// commands, independently of the transport Redis and Zenith.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/gravito-framework/quasar-go/pkg/types"
+)
+
+// Message is a rendered notification for a single event
+type Message struct {
+	Service  string      `json:"service"`
+	Node     string      `json:"node"`
+	Hostname string      `json:"hostname"`
+	Text     string      `json:"text"`
+	Event    types.Event `json:"event"`
+}
+
+// Notifier delivers a message to one destination
+type Notifier interface {
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// ============================================
+// Webhook
+// ============================================
+
+// WebhookNotifier POSTs the full message as JSON to a generic webhook
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a generic JSON webhook notifier
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: &http.Client{}}
+}
+
+// Name returns the notifier name
+func (n *WebhookNotifier) Name() string { return "webhook" }
+
+// Send posts the message
+func (n *WebhookNotifier) Send(ctx context.Context, msg Message) error {
+	return postJSON(ctx, n.client, n.url, msg)
+}
+
+// ============================================
+// Slack
+// ============================================
+
+// SlackNotifier posts the rendered text to a Slack-compatible incoming webhook
+type SlackNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewSlackNotifier creates a Slack-compatible incoming webhook notifier
+func NewSlackNotifier(url string) *SlackNotifier {
+	return &SlackNotifier{url: url, client: &http.Client{}}
+}
+
+// Name returns the notifier name
+func (n *SlackNotifier) Name() string { return "slack" }
+
+// Send posts the message text
+func (n *SlackNotifier) Send(ctx context.Context, msg Message) error {
+	return postJSON(ctx, n.client, n.url, map[string]string{"text": msg.Text})
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "quasar-agent")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// ============================================
+// Local command
+// ============================================
+
+// CommandNotifier runs a local command for each message.
+// The message JSON is written to stdin and the main fields are exported as
+// QUASAR_EVENT_* environment variables. The command is not run through a shell.
+type CommandNotifier struct {
+	args []string
+}
+
+// NewCommandNotifier creates a local command notifier
+func NewCommandNotifier(command string) (*CommandNotifier, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty notify command")
+	}
+	return &CommandNotifier{args: args}, nil
+}
+
+// Name returns the notifier name
+func (n *CommandNotifier) Name() string { return "command" }
+
+// Send runs the command and waits for it to exit
+func (n *CommandNotifier) Send(ctx context.Context, msg Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, n.args[0], n.args[1:]...)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Env = append(os.Environ(),
+		"QUASAR_EVENT_TYPE="+msg.Event.Type,
+		"QUASAR_EVENT_SEVERITY="+string(msg.Event.Severity),
+		"QUASAR_EVENT_MESSAGE="+msg.Event.Message,
+		"QUASAR_EVENT_TEXT="+msg.Text,
+		"QUASAR_EVENT_SERVICE="+msg.Service,
+		"QUASAR_EVENT_NODE="+msg.Node,
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
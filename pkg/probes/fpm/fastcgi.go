@@ -0,0 +1,182 @@
+package fpm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FastCGI protocol constants (see the FastCGI 1.0 specification)
+const (
+	fcgiVersion      = 1
+	fcgiBeginRequest = 1
+	fcgiEndRequest   = 3
+	fcgiParams       = 4
+	fcgiStdin        = 5
+	fcgiStdout       = 6
+	fcgiStderr       = 7
+	fcgiResponder    = 1
+	fcgiRequestID    = 1
+	fcgiMaxContent   = 65535
+)
+
+// fcgiHeader is the fixed 8-byte header preceding every record
+type fcgiHeader struct {
+	Version       uint8
+	Type          uint8
+	RequestID     uint16
+	ContentLength uint16
+	PaddingLength uint8
+	Reserved      uint8
+}
+
+// fcgiGet performs a single GET request against a FastCGI responder
+// (network is "unix" or "tcp") and returns the HTTP status and body.
+func fcgiGet(ctx context.Context, network, address string, params map[string]string) (int, []byte, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, network, address)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	} else {
+		_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+	}
+
+	w := bufio.NewWriter(conn)
+
+	// BEGIN_REQUEST: role responder, close connection when done
+	begin := []byte{0, fcgiResponder, 0, 0, 0, 0, 0, 0}
+	if err := writeRecord(w, fcgiBeginRequest, begin); err != nil {
+		return 0, nil, err
+	}
+	if err := writeRecord(w, fcgiParams, encodeParams(params)); err != nil {
+		return 0, nil, err
+	}
+	// Empty PARAMS and STDIN records terminate both streams
+	if err := writeRecord(w, fcgiParams, nil); err != nil {
+		return 0, nil, err
+	}
+	if err := writeRecord(w, fcgiStdin, nil); err != nil {
+		return 0, nil, err
+	}
+	if err := w.Flush(); err != nil {
+		return 0, nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	r := bufio.NewReader(conn)
+	for {
+		var h fcgiHeader
+		if err := binary.Read(r, binary.BigEndian, &h); err != nil {
+			return 0, nil, fmt.Errorf("read fastcgi record: %w", err)
+		}
+
+		content := make([]byte, int(h.ContentLength)+int(h.PaddingLength))
+		if _, err := io.ReadFull(r, content); err != nil {
+			return 0, nil, fmt.Errorf("read fastcgi content: %w", err)
+		}
+		content = content[:h.ContentLength]
+
+		switch h.Type {
+		case fcgiStdout:
+			stdout.Write(content)
+		case fcgiStderr:
+			stderr.Write(content)
+		case fcgiEndRequest:
+			return parseCGIResponse(stdout.Bytes(), stderr.String())
+		}
+	}
+}
+
+// writeRecord writes content as one or more records of the given type
+func writeRecord(w io.Writer, recType uint8, content []byte) error {
+	for {
+		chunk := content
+		if len(chunk) > fcgiMaxContent {
+			chunk = chunk[:fcgiMaxContent]
+		}
+
+		padding := uint8((8 - len(chunk)%8) % 8)
+		h := fcgiHeader{
+			Version:       fcgiVersion,
+			Type:          recType,
+			RequestID:     fcgiRequestID,
+			ContentLength: uint16(len(chunk)),
+			PaddingLength: padding,
+		}
+		if err := binary.Write(w, binary.BigEndian, h); err != nil {
+			return err
+		}
+		if _, err := w.Write(chunk); err != nil {
+			return err
+		}
+		if _, err := w.Write(make([]byte, padding)); err != nil {
+			return err
+		}
+
+		content = content[len(chunk):]
+		if len(content) == 0 {
+			return nil
+		}
+	}
+}
+
+// encodeParams encodes FastCGI name-value pairs
+func encodeParams(params map[string]string) []byte {
+	var buf bytes.Buffer
+	for name, value := range params {
+		writeParamLength(&buf, len(name))
+		writeParamLength(&buf, len(value))
+		buf.WriteString(name)
+		buf.WriteString(value)
+	}
+	return buf.Bytes()
+}
+
+// writeParamLength uses the 1-byte form for lengths < 128, else the 4-byte form
+func writeParamLength(buf *bytes.Buffer, n int) {
+	if n < 128 {
+		buf.WriteByte(byte(n))
+		return
+	}
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(n)|1<<31)
+	buf.Write(b[:])
+}
+
+// parseCGIResponse splits CGI output into status and body
+func parseCGIResponse(stdout []byte, stderr string) (int, []byte, error) {
+	tp := textproto.NewReader(bufio.NewReader(bytes.NewReader(stdout)))
+	headers, err := tp.ReadMIMEHeader()
+	if err != nil && err != io.EOF {
+		return 0, nil, fmt.Errorf("invalid fastcgi response headers: %w", err)
+	}
+
+	body, _ := io.ReadAll(tp.R)
+
+	status := 200
+	if v := headers.Get("Status"); v != "" {
+		code, _, _ := strings.Cut(v, " ")
+		if n, err := strconv.Atoi(code); err == nil {
+			status = n
+		}
+	}
+
+	if status >= 400 && stderr != "" {
+		return status, body, fmt.Errorf("fastcgi error: %s", strings.TrimSpace(stderr))
+	}
+
+	return status, body, nil
+}
@@ -0,0 +1,205 @@
+// Package fpm provides a PHP-FPM status page probe.
+package fpm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// PoolStatus contains the status of a single PHP-FPM pool
+type PoolStatus struct {
+	Pool               string `json:"pool"`
+	ProcessManager     string `json:"processManager"`
+	AcceptedConn       int64  `json:"acceptedConn"`
+	ListenQueue        int64  `json:"listenQueue"`    // Requests waiting for a free process
+	MaxListenQueue     int64  `json:"maxListenQueue"` // High-water mark since FPM start
+	ListenQueueLen     int64  `json:"listenQueueLen"` // Socket backlog size
+	IdleProcesses      int64  `json:"idleProcesses"`
+	ActiveProcesses    int64  `json:"activeProcesses"`
+	TotalProcesses     int64  `json:"totalProcesses"`
+	MaxActiveProcesses int64  `json:"maxActiveProcesses"`
+	MaxChildrenReached int64  `json:"maxChildrenReached"` // Times pm.max_children was hit
+	SlowRequests       int64  `json:"slowRequests"`
+	StartSince         int64  `json:"startSince"` // Seconds since FPM start
+}
+
+// rawStatus mirrors the JSON emitted by the FPM status page (?json)
+type rawStatus struct {
+	Pool               string `json:"pool"`
+	ProcessManager     string `json:"process manager"`
+	StartSince         int64  `json:"start since"`
+	AcceptedConn       int64  `json:"accepted conn"`
+	ListenQueue        int64  `json:"listen queue"`
+	MaxListenQueue     int64  `json:"max listen queue"`
+	ListenQueueLen     int64  `json:"listen queue len"`
+	IdleProcesses      int64  `json:"idle processes"`
+	ActiveProcesses    int64  `json:"active processes"`
+	TotalProcesses     int64  `json:"total processes"`
+	MaxActiveProcesses int64  `json:"max active processes"`
+	MaxChildrenReached int64  `json:"max children reached"`
+	SlowRequests       int64  `json:"slow requests"`
+}
+
+// Probe reads a PHP-FPM status page over HTTP or directly over FastCGI
+type Probe struct {
+	name       string
+	scheme     string // "http", "https", "unix" or "tcp"
+	address    string // URL for HTTP, socket path or host:port for FastCGI
+	statusPath string // pm.status_path, used for FastCGI only
+	timeout    time.Duration
+	httpClient *http.Client
+}
+
+// NewProbe creates a probe for one pool. Supported addresses:
+//   - http(s)://host/status          (status page behind a web server)
+//   - unix:///run/php/php-fpm.sock   (FastCGI over a unix socket)
+//   - tcp://127.0.0.1:9000           (FastCGI over TCP)
+//
+// statusPath is the pool's pm.status_path (default: /status).
+func NewProbe(name, address, statusPath string) (*Probe, error) {
+	if statusPath == "" {
+		statusPath = "/status"
+	}
+
+	p := &Probe{
+		name:       name,
+		statusPath: statusPath,
+		timeout:    5 * time.Second,
+	}
+
+	if strings.HasPrefix(address, "/") {
+		address = "unix://" + address
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FPM address %q: %w", address, err)
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		p.scheme = u.Scheme
+		p.address = address
+		p.httpClient = &http.Client{Timeout: p.timeout}
+	case "unix":
+		p.scheme = "unix"
+		p.address = u.Path
+	case "tcp":
+		p.scheme = "tcp"
+		p.address = u.Host
+	default:
+		return nil, fmt.Errorf("unsupported FPM address scheme %q (use http, https, unix or tcp)", u.Scheme)
+	}
+
+	return p, nil
+}
+
+// Name returns the configured pool name
+func (p *Probe) Name() string {
+	return p.name
+}
+
+// GetStatus fetches and parses the pool status
+func (p *Probe) GetStatus(ctx context.Context) (*PoolStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	var body []byte
+	var err error
+	if p.httpClient != nil {
+		body, err = p.fetchHTTP(ctx)
+	} else {
+		body, err = p.fetchFastCGI(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return parseStatus(body, p.name)
+}
+
+func (p *Probe) fetchHTTP(ctx context.Context) ([]byte, error) {
+	u, err := url.Parse(p.address)
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	q.Set("json", "")
+	u.RawQuery = strings.TrimSuffix(q.Encode(), "=")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("FPM status page returned %s", resp.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+func (p *Probe) fetchFastCGI(ctx context.Context) ([]byte, error) {
+	params := map[string]string{
+		"GATEWAY_INTERFACE": "CGI/1.1",
+		"REQUEST_METHOD":    "GET",
+		"SCRIPT_NAME":       p.statusPath,
+		"SCRIPT_FILENAME":   p.statusPath,
+		"REQUEST_URI":       p.statusPath + "?json",
+		"QUERY_STRING":      "json",
+		"SERVER_PROTOCOL":   "HTTP/1.1",
+		"SERVER_SOFTWARE":   "quasar",
+		"REMOTE_ADDR":       "127.0.0.1",
+	}
+
+	status, body, err := fcgiGet(ctx, p.scheme, p.address, params)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("FPM status returned %d (is pm.status_path = %s?)", status, p.statusPath)
+	}
+
+	return body, nil
+}
+
+// parseStatus converts the FPM JSON status into a PoolStatus
+func parseStatus(body []byte, fallbackName string) (*PoolStatus, error) {
+	var raw rawStatus
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("invalid FPM status JSON: %w", err)
+	}
+
+	pool := raw.Pool
+	if pool == "" {
+		pool = fallbackName
+	}
+
+	return &PoolStatus{
+		Pool:               pool,
+		ProcessManager:     raw.ProcessManager,
+		AcceptedConn:       raw.AcceptedConn,
+		ListenQueue:        raw.ListenQueue,
+		MaxListenQueue:     raw.MaxListenQueue,
+		ListenQueueLen:     raw.ListenQueueLen,
+		IdleProcesses:      raw.IdleProcesses,
+		ActiveProcesses:    raw.ActiveProcesses,
+		TotalProcesses:     raw.TotalProcesses,
+		MaxActiveProcesses: raw.MaxActiveProcesses,
+		MaxChildrenReached: raw.MaxChildrenReached,
+		SlowRequests:       raw.SlowRequests,
+		StartSince:         raw.StartSince,
+	}, nil
+}
@@ -0,0 +1,115 @@
+package fpm
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/fcgi"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+// statusJSON is a real php-fpm 8.2 status page (?json), without the process list
+const statusJSON = `{"pool":"www","process manager":"dynamic","start time":1767225600,"start since":3600,` +
+	`"accepted conn":1542,"listen queue":3,"max listen queue":12,"listen queue len":511,` +
+	`"idle processes":1,"active processes":4,"total processes":5,"max active processes":5,` +
+	`"max children reached":2,"slow requests":7}`
+
+// statusHandler stands in for FPM's status page
+func statusHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["json"]; !ok {
+			t.Errorf("Expected ?json query, got %q", r.URL.RawQuery)
+		}
+		if r.URL.Path != "/status" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(statusJSON))
+	})
+}
+
+func assertStatus(t *testing.T, status *PoolStatus) {
+	t.Helper()
+
+	if status.Pool != "www" || status.ProcessManager != "dynamic" {
+		t.Errorf("Unexpected pool identity: %+v", status)
+	}
+	if status.ActiveProcesses != 4 || status.IdleProcesses != 1 || status.TotalProcesses != 5 {
+		t.Errorf("Unexpected process counts: %+v", status)
+	}
+	if status.ListenQueue != 3 || status.MaxChildrenReached != 2 || status.SlowRequests != 7 {
+		t.Errorf("Unexpected saturation figures: %+v", status)
+	}
+}
+
+func TestProbeFastCGIUnix(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "fpm.sock")
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() { _ = fcgi.Serve(ln, statusHandler(t)) }()
+
+	probe, err := NewProbe("www", "unix://"+socket, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := probe.GetStatus(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertStatus(t, status)
+}
+
+func TestProbeFastCGITCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() { _ = fcgi.Serve(ln, statusHandler(t)) }()
+
+	probe, err := NewProbe("www", "tcp://"+ln.Addr().String(), "/status")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := probe.GetStatus(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertStatus(t, status)
+
+	// Wrong status path surfaces as an error
+	probe, _ = NewProbe("www", "tcp://"+ln.Addr().String(), "/fpm-status")
+	if _, err := probe.GetStatus(context.Background()); err == nil {
+		t.Error("Expected error for wrong status path")
+	}
+}
+
+func TestProbeHTTP(t *testing.T) {
+	server := httptest.NewServer(statusHandler(t))
+	defer server.Close()
+
+	probe, err := NewProbe("www", server.URL+"/status", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := probe.GetStatus(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertStatus(t, status)
+}
+
+func TestNewProbeInvalidScheme(t *testing.T) {
+	if _, err := NewProbe("www", "ftp://localhost", ""); err == nil {
+		t.Error("Expected error for unsupported scheme")
+	}
+}
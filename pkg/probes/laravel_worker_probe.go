@@ -1,19 +1,28 @@
 package probes
 
 import (
+	"slices"
+	"sort"
 	"strings"
 	"sync" // Added for mutex
+	"time"
 
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
 	"github.com/shirou/gopsutil/v3/process"
 )
 
 // LaravelWorkerDetail contains details for a single worker process
 type LaravelWorkerDetail struct {
-	PID     int32   `json:"pid"`
-	Cmdline string  `json:"cmdline"`
-	Memory  uint64  `json:"memory"` // RSS in bytes
-	CPU     float64 `json:"cpu"`    // Percent
-	Status  string  `json:"status"` // "running", "sleeping", etc.
+	PID      int32    `json:"pid"`
+	Cmdline  string   `json:"cmdline"`
+	Memory   uint64   `json:"memory"` // RSS in bytes
+	CPU      float64  `json:"cpu"`    // Percent
+	Status   string   `json:"status"` // "running", "sleeping", etc.
+	Root     string   `json:"root,omitempty"`
+	Queues   []string `json:"queues,omitempty"` // Queues served, from --queue
+	Uptime   float64  `json:"uptime"`           // Seconds since process start
+	Restarts int      `json:"restarts"`         // Times this worker's slot was restarted, see WorkerCrashLoop.Slot
+	Paused   bool     `json:"paused,omitempty"` // Paused by the agent with SIGUSR2
 }
 
 // LaravelWorkerStats contains information about running Laravel workers
@@ -21,14 +30,53 @@ type LaravelWorkerStats struct {
 	WorkerCount int                   `json:"workerCount"`
 	Roots       []string              `json:"roots"`
 	Workers     []LaravelWorkerDetail `json:"workers"`
+	CrashLoops  []WorkerCrashLoop     `json:"crashLoops,omitempty"`
+	Projects    []LaravelProjectStats `json:"projects,omitempty"` // Per-project totals, see GroupByProject
+}
+
+// LaravelProjectStats sums up the workers of one Laravel project
+type LaravelProjectStats struct {
+	Name        string   `json:"name"`
+	Root        string   `json:"root,omitempty"`
+	WorkerCount int      `json:"workerCount"`
+	Memory      uint64   `json:"memory"` // Total RSS in bytes
+	CPU         float64  `json:"cpu"`    // Total percent
+	Restarts    int      `json:"restarts"`
+	Queues      []string `json:"queues,omitempty"`
 }
 
 var (
 	// workerProcessCache maintains state for process CPU calculations
 	workerProcessCache = make(map[int32]*process.Process)
 	cacheMutex         sync.Mutex
+
+	// pausedWorkers holds workers paused via signal: PID -> create time (ms).
+	// Laravel keeps a paused worker's state internal, so we remember it ourselves.
+	pausedWorkers = make(map[int32]int64)
 )
 
+// SetWorkerPaused records that a worker was paused or resumed by signal
+func SetWorkerPaused(pid int32, createTime int64, paused bool) {
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+
+	if paused {
+		pausedWorkers[pid] = createTime
+	} else if pausedWorkers[pid] == createTime {
+		// A reused PID keeps its own entry
+		delete(pausedWorkers, pid)
+	}
+}
+
+// IsWorkerPaused reports whether the agent paused this worker
+func IsWorkerPaused(pid int32, createTime int64) bool {
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+
+	pausedAt, ok := pausedWorkers[pid]
+	return ok && pausedAt == createTime
+}
+
 // GetLaravelWorkerStats scans the system for php artisan queue:work or artisan horizon processes
 func GetLaravelWorkerStats() *LaravelWorkerStats {
 	allProcs, err := process.Processes()
@@ -39,7 +87,9 @@ func GetLaravelWorkerStats() *LaravelWorkerStats {
 	cacheMutex.Lock()
 	defer cacheMutex.Unlock()
 
+	now := time.Now()
 	activePids := make(map[int32]bool)
+	records := make(map[int32]workerRecord)
 	workers := []LaravelWorkerDetail{}
 	rootsMap := make(map[string]bool)
 	workerCount := 0
@@ -65,7 +115,7 @@ func GetLaravelWorkerStats() *LaravelWorkerStats {
 			continue
 		}
 
-		if strings.Contains(cmdline, "artisan") && (strings.Contains(cmdline, "queue:work") || strings.Contains(cmdline, "horizon")) {
+		if IsLaravelWorkerCmdline(cmdline) {
 			workerCount++
 
 			var memRSS uint64 = 0
@@ -93,12 +143,33 @@ func GetLaravelWorkerStats() *LaravelWorkerStats {
 				rootsMap[cwd] = true
 			}
 
+			queues := ParseWorkerQueues(cmdline)
+			createTime, _ := proc.CreateTime()
+			records[proc.Pid] = workerRecord{
+				identity:   workerIdentity(cwd, cmdline),
+				process:    supervisorProcessName(proc),
+				root:       cwd,
+				cmdline:    cmdline,
+				queues:     queues,
+				createTime: createTime,
+			}
+
+			var uptime float64
+			if createTime > 0 {
+				uptime = round(now.Sub(time.UnixMilli(createTime)).Seconds(), 0)
+			}
+
+			pausedAt, paused := pausedWorkers[proc.Pid]
 			workers = append(workers, LaravelWorkerDetail{
 				PID:     proc.Pid,
 				Cmdline: cmdline,
 				Memory:  memRSS,
 				CPU:     cpuPercent,
 				Status:  status,
+				Root:    cwd,
+				Queues:  queues,
+				Uptime:  uptime,
+				Paused:  paused && pausedAt == createTime,
 			})
 		}
 	}
@@ -109,6 +180,17 @@ func GetLaravelWorkerStats() *LaravelWorkerStats {
 			delete(workerProcessCache, pid)
 		}
 	}
+	for pid, createTime := range pausedWorkers {
+		if record, ok := records[pid]; !ok || record.createTime != createTime {
+			delete(pausedWorkers, pid)
+		}
+	}
+
+	// Lifecycle events, restart counts and crash loops
+	lifecycle.observe(records, now)
+	for i := range workers {
+		workers[i].Restarts = lifecycle.restartCount(records[workers[i].PID].slot)
+	}
 
 	roots := make([]string, 0, len(rootsMap))
 	for root := range rootsMap {
@@ -119,5 +201,141 @@ func GetLaravelWorkerStats() *LaravelWorkerStats {
 		WorkerCount: workerCount,
 		Roots:       roots,
 		Workers:     workers,
+		CrashLoops:  lifecycle.crashLoops(),
+	}
+}
+
+// GroupByProject fills Projects from the workers. nameFor maps a worker root
+// to its project name; configured projects are listed even without workers.
+func (s *LaravelWorkerStats) GroupByProject(nameFor func(root string) string, configured []laravel.Project) {
+	byName := make(map[string]*LaravelProjectStats)
+	var order []string
+	add := func(name, root string) *LaravelProjectStats {
+		if stats, ok := byName[name]; ok {
+			return stats
+		}
+		byName[name] = &LaravelProjectStats{Name: name, Root: root}
+		order = append(order, name)
+		return byName[name]
+	}
+
+	for _, project := range configured {
+		add(project.Name, project.Root)
+	}
+	for _, w := range s.Workers {
+		if w.Root == "" {
+			continue
+		}
+		stats := add(nameFor(w.Root), w.Root)
+		stats.WorkerCount++
+		stats.Memory += w.Memory
+		stats.CPU += w.CPU
+		stats.Restarts += w.Restarts
+		for _, q := range w.Queues {
+			if !slices.Contains(stats.Queues, q) {
+				stats.Queues = append(stats.Queues, q)
+			}
+		}
+	}
+
+	s.Projects = make([]LaravelProjectStats, 0, len(order))
+	for _, name := range order {
+		stats := byName[name]
+		sort.Strings(stats.Queues)
+		s.Projects = append(s.Projects, *stats)
+	}
+}
+
+// supervisorProcessName returns the group:name a worker runs as under
+// Supervisor, from the environment Supervisor gives its processes, or ""
+// if it has none or it can't be read
+func supervisorProcessName(proc *process.Process) string {
+	env, err := proc.Environ()
+	if err != nil {
+		return ""
+	}
+	var group, name string
+	for _, kv := range env {
+		if v, ok := strings.CutPrefix(kv, "SUPERVISOR_GROUP_NAME="); ok {
+			group = v
+		} else if v, ok := strings.CutPrefix(kv, "SUPERVISOR_PROCESS_NAME="); ok {
+			name = v
+		}
+	}
+	if name == "" {
+		return ""
+	}
+	return group + ":" + name
+}
+
+// DiscoverLaravelProjects finds Laravel projects from the cwd of running
+// workers. They run artisan with php from PATH; configure a project to use
+// another binary.
+func DiscoverLaravelProjects() ([]laravel.Project, error) {
+	procs, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var projects []laravel.Project
+	for _, p := range procs {
+		cmdline, err := p.Cmdline()
+		if err != nil || !IsLaravelWorkerCmdline(cmdline) {
+			continue
+		}
+		cwd, err := p.Cwd()
+		if err != nil || cwd == "" || seen[cwd] {
+			continue
+		}
+		seen[cwd] = true
+
+		// Never the worker's own executable: the agent runs this binary,
+		// and anyone can start a process that looks like a worker
+		projects = append(projects, laravel.Project{Name: laravel.ProjectName(cwd), Root: cwd, PHP: "php"})
+	}
+	return projects, nil
+}
+
+// IsLaravelWorkerCmdline checks if a command line looks like 'artisan queue:work' or 'horizon'
+func IsLaravelWorkerCmdline(cmdline string) bool {
+	return strings.Contains(cmdline, "artisan") &&
+		(strings.Contains(cmdline, "queue:work") || strings.Contains(cmdline, "horizon"))
+}
+
+// IsJobWorkerCmdline reports whether a process runs jobs itself (queue:work or
+// horizon:work), as opposed to the Horizon master and supervisor processes
+// that only manage a pool
+func IsJobWorkerCmdline(cmdline string) bool {
+	fields := strings.Fields(cmdline)
+	return slices.Contains(fields, "queue:work") || slices.Contains(fields, "horizon:work")
+}
+
+// ParseWorkerQueues extracts the queues a worker serves from its --queue option.
+// A queue:work process without --queue serves the connection's "default" queue.
+func ParseWorkerQueues(cmdline string) []string {
+	fields := strings.Fields(cmdline)
+	for i, field := range fields {
+		var value string
+		if v, ok := strings.CutPrefix(field, "--queue="); ok {
+			value = v
+		} else if field == "--queue" && i+1 < len(fields) {
+			value = fields[i+1]
+		} else {
+			continue
+		}
+
+		var queues []string
+		for _, q := range strings.Split(value, ",") {
+			if q = strings.TrimSpace(q); q != "" {
+				queues = append(queues, q)
+			}
+		}
+		return queues
+	}
+
+	if strings.Contains(cmdline, "queue:work") {
+		return []string{"default"}
 	}
+	return nil
 }
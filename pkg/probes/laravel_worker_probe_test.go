@@ -0,0 +1,84 @@
+package probes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+)
+
+func TestParseWorkerQueues(t *testing.T) {
+	tests := []struct {
+		name     string
+		cmdline  string
+		expected []string
+	}{
+		{"equals form", "php artisan queue:work redis --queue=high,default --tries=3", []string{"high", "default"}},
+		{"space form", "php artisan queue:work --queue emails", []string{"emails"}},
+		{"no queue option", "php /var/www/artisan queue:work redis", []string{"default"}},
+		{"horizon master", "php artisan horizon", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseWorkerQueues(tt.cmdline)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestIsLaravelWorkerCmdline(t *testing.T) {
+	if !IsLaravelWorkerCmdline("php artisan queue:work redis") {
+		t.Error("Expected queue:work to be a worker")
+	}
+	if !IsLaravelWorkerCmdline("php artisan horizon:work redis --queue=default") {
+		t.Error("Expected horizon:work to be a worker")
+	}
+	if IsLaravelWorkerCmdline("php artisan schedule:run") {
+		t.Error("Expected schedule:run not to be a worker")
+	}
+}
+
+func TestGroupByProject(t *testing.T) {
+	stats := &LaravelWorkerStats{Workers: []LaravelWorkerDetail{
+		{PID: 1, Root: "/var/www/shop", Memory: 100, CPU: 1, Queues: []string{"emails", "default"}},
+		{PID: 2, Root: "/var/www/shop", Memory: 50, CPU: 2, Restarts: 3, Queues: []string{"default"}},
+		{PID: 3, Root: "/srv/blog", Memory: 10, Queues: []string{"default"}},
+	}}
+
+	names := map[string]string{"/var/www/shop": "shop"}
+	nameFor := func(root string) string {
+		if name, ok := names[root]; ok {
+			return name
+		}
+		return laravel.ProjectName(root)
+	}
+	stats.GroupByProject(nameFor, []laravel.Project{
+		{Name: "api", Root: "/var/www/api"},
+		{Name: "shop", Root: "/var/www/shop"},
+	})
+
+	expected := []LaravelProjectStats{
+		{Name: "api", Root: "/var/www/api"},
+		{Name: "shop", Root: "/var/www/shop", WorkerCount: 2, Memory: 150, CPU: 3, Restarts: 3, Queues: []string{"default", "emails"}},
+		{Name: "blog", Root: "/srv/blog", WorkerCount: 1, Memory: 10, Queues: []string{"default"}},
+	}
+	if !reflect.DeepEqual(stats.Projects, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, stats.Projects)
+	}
+}
+
+func TestSetWorkerPausedKeepsReusedPID(t *testing.T) {
+	SetWorkerPaused(4242, 1, true)
+	SetWorkerPaused(4242, 2, false) // A different process with the same PID
+	if !IsWorkerPaused(4242, 1) {
+		t.Error("Expected pause record of the original process to be kept")
+	}
+
+	SetWorkerPaused(4242, 1, false)
+	if IsWorkerPaused(4242, 1) {
+		t.Error("Expected pause record to be removed")
+	}
+}
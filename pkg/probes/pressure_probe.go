@@ -0,0 +1,151 @@
+package probes
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gravito-framework/quasar-go/pkg/types"
+)
+
+// PressureProbe reads Linux Pressure Stall Information (PSI).
+// Host-wide figures come from /proc/pressure/{cpu,memory,io} and cgroup-level
+// figures from the {cpu,memory,io}.pressure files of a cgroup v2 directory.
+// On kernels without PSI (or non-Linux hosts) it reports nothing.
+type PressureProbe struct {
+	procDir   string // Usually /proc/pressure
+	cgroupDir string // cgroup v2 directory, empty if unknown
+}
+
+// NewPressureProbe creates a PSI probe. If cgroupDir is empty, the agent's own
+// cgroup v2 directory is detected from /proc/self/cgroup.
+func NewPressureProbe(cgroupDir string) *PressureProbe {
+	if cgroupDir == "" {
+		cgroupDir = detectCgroupDir("/proc/self/cgroup", "/sys/fs/cgroup")
+	}
+	return &PressureProbe{
+		procDir:   "/proc/pressure",
+		cgroupDir: cgroupDir,
+	}
+}
+
+// GetPressure returns current PSI figures, or nil when PSI is unavailable
+func (p *PressureProbe) GetPressure() *types.PressureInfo {
+	system := readPressureDir(p.procDir, "")
+
+	var cgroup *types.PressureMetrics
+	if p.cgroupDir != "" {
+		cgroup = readPressureDir(p.cgroupDir, ".pressure")
+	}
+
+	if system == nil && cgroup == nil {
+		return nil
+	}
+
+	return &types.PressureInfo{
+		System: system,
+		Cgroup: cgroup,
+	}
+}
+
+// readPressureDir reads cpu, memory and io pressure files from dir.
+// File names are "<resource><suffix>" (e.g. "cpu" or "cpu.pressure").
+func readPressureDir(dir, suffix string) *types.PressureMetrics {
+	metrics := &types.PressureMetrics{
+		CPU:    readPressureFile(filepath.Join(dir, "cpu"+suffix)),
+		Memory: readPressureFile(filepath.Join(dir, "memory"+suffix)),
+		IO:     readPressureFile(filepath.Join(dir, "io"+suffix)),
+	}
+
+	if metrics.CPU == nil && metrics.Memory == nil && metrics.IO == nil {
+		return nil
+	}
+	return metrics
+}
+
+// readPressureFile parses a PSI file, returning nil if it is missing or unreadable
+func readPressureFile(path string) *types.PressureStats {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return parsePressure(string(data))
+}
+
+// parsePressure parses PSI file content:
+//
+//	some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+func parsePressure(content string) *types.PressureStats {
+	stats := &types.PressureStats{}
+
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		parsed := &types.PressureLine{}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "avg10":
+				parsed.Avg10, _ = strconv.ParseFloat(value, 64)
+			case "avg60":
+				parsed.Avg60, _ = strconv.ParseFloat(value, 64)
+			case "avg300":
+				parsed.Avg300, _ = strconv.ParseFloat(value, 64)
+			case "total":
+				parsed.Total, _ = strconv.ParseUint(value, 10, 64)
+			}
+		}
+
+		switch fields[0] {
+		case "some":
+			stats.Some = parsed
+		case "full":
+			stats.Full = parsed
+		}
+	}
+
+	if stats.Some == nil && stats.Full == nil {
+		return nil
+	}
+	return stats
+}
+
+// detectCgroupDir resolves the cgroup v2 directory of the current process.
+// It supports both pure v2 hosts and hybrid hosts (v2 mounted at <root>/unified).
+func detectCgroupDir(selfCgroup, cgroupRoot string) string {
+	f, err := os.Open(selfCgroup)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	var relPath string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// cgroup v2 entries have the form "0::/path"
+		if rest, ok := strings.CutPrefix(scanner.Text(), "0::"); ok {
+			relPath = rest
+			break
+		}
+	}
+	if relPath == "" {
+		return ""
+	}
+
+	for _, root := range []string{cgroupRoot, filepath.Join(cgroupRoot, "unified")} {
+		if _, err := os.Stat(filepath.Join(root, "cgroup.controllers")); err == nil {
+			return filepath.Join(root, relPath)
+		}
+	}
+
+	return ""
+}
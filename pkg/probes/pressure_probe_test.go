@@ -0,0 +1,87 @@
+package probes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParsePressure(t *testing.T) {
+	content := "some avg10=1.88 avg60=2.27 avg300=2.12 total=12749238\nfull avg10=0.50 avg60=0.00 avg300=0.00 total=42\n"
+
+	stats := parsePressure(content)
+	if stats == nil || stats.Some == nil || stats.Full == nil {
+		t.Fatalf("Expected some and full lines, got %+v", stats)
+	}
+
+	if stats.Some.Avg10 != 1.88 || stats.Some.Avg300 != 2.12 || stats.Some.Total != 12749238 {
+		t.Errorf("Unexpected some line: %+v", stats.Some)
+	}
+
+	if stats.Full.Avg10 != 0.5 || stats.Full.Total != 42 {
+		t.Errorf("Unexpected full line: %+v", stats.Full)
+	}
+
+	// Older kernels only report "some" for CPU
+	stats = parsePressure("some avg10=3.00 avg60=0.00 avg300=0.00 total=1\n")
+	if stats == nil || stats.Full != nil {
+		t.Errorf("Expected only some line, got %+v", stats)
+	}
+
+	if parsePressure("") != nil {
+		t.Error("Expected nil for empty content")
+	}
+}
+
+func TestPressureProbeWithoutPSI(t *testing.T) {
+	probe := &PressureProbe{procDir: filepath.Join(t.TempDir(), "missing")}
+
+	if info := probe.GetPressure(); info != nil {
+		t.Errorf("Expected nil without PSI, got %+v", info)
+	}
+}
+
+func TestPressureProbeCgroup(t *testing.T) {
+	dir := t.TempDir()
+	content := []byte("some avg10=10.00 avg60=5.00 avg300=1.00 total=100\nfull avg10=2.00 avg60=1.00 avg300=0.50 total=10\n")
+	if err := os.WriteFile(filepath.Join(dir, "memory.pressure"), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	probe := &PressureProbe{procDir: filepath.Join(dir, "missing"), cgroupDir: dir}
+	info := probe.GetPressure()
+	if info == nil || info.Cgroup == nil {
+		t.Fatalf("Expected cgroup pressure, got %+v", info)
+	}
+
+	if v, ok := info.Cgroup.Value("memory", "full", "avg10"); !ok || v != 2 {
+		t.Errorf("Expected memory.full.avg10 = 2, got %v (ok=%v)", v, ok)
+	}
+
+	if _, ok := info.Cgroup.Value("cpu", "some", "avg10"); ok {
+		t.Error("Expected cpu pressure to be unavailable")
+	}
+}
+
+func TestDetectCgroupDir(t *testing.T) {
+	dir := t.TempDir()
+	selfCgroup := filepath.Join(dir, "cgroup")
+	root := filepath.Join(dir, "fs")
+
+	if err := os.WriteFile(selfCgroup, []byte("4:memory:/x\n0::/system.slice/app.service\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Hybrid layout: cgroup v2 mounted at <root>/unified
+	if err := os.MkdirAll(filepath.Join(root, "unified"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "unified", "cgroup.controllers"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := filepath.Join(root, "unified", "system.slice", "app.service")
+	if got := detectCgroupDir(selfCgroup, root); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
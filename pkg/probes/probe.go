@@ -18,6 +18,10 @@ type SystemMetrics struct {
 	Uptime   float64 // seconds
 	CPU      types.CPUMetrics
 	Memory   types.MemoryMetrics
+	Disks    []types.DiskMetrics    // Optional: per-mountpoint usage
+	DiskIO   []types.DiskIOMetrics  // Optional: per-device IO rates
+	Network  []types.NetworkMetrics // Optional: per-interface throughput
+	Load     *types.LoadAverage     // Optional: nil where unsupported
 }
 
 // QueueProbe collects queue state snapshot
@@ -0,0 +1,252 @@
+package probes
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// ProcessGroupSpec describes which processes belong to a monitored group.
+// Every non-empty criterion must match for a process to be included.
+type ProcessGroupSpec struct {
+	Name    string // Group name, used as the key in the heartbeat
+	Exe     string // Glob on the executable base name (e.g. "php-fpm*")
+	Cmdline string // Regular expression on the full command line
+	User    string // Exact owner username
+	Cwd     string // Glob on the working directory (e.g. "/var/www/*")
+}
+
+// ProcessDetail contains details for a single process in a group
+type ProcessDetail struct {
+	PID     int32   `json:"pid"`
+	Cmdline string  `json:"cmdline"`
+	User    string  `json:"user,omitempty"`
+	Memory  uint64  `json:"memory"`  // RSS in bytes
+	CPU     float64 `json:"cpu"`     // Percent of one core
+	FDs     int32   `json:"fds"`     // Open file descriptors (0 if not permitted)
+	Threads int32   `json:"threads"` // Thread count
+	Uptime  float64 `json:"uptime"`  // Seconds since process start
+}
+
+// ProcessGroupStats contains aggregate and per-process data for a group
+type ProcessGroupStats struct {
+	Count     int             `json:"count"`
+	CPU       float64         `json:"cpu"`      // Sum of member CPU %
+	Memory    uint64          `json:"memory"`   // Sum of member RSS in bytes
+	FDs       int32           `json:"fds"`      // Sum of member open FDs
+	Threads   int32           `json:"threads"`  // Sum of member threads
+	Restarts  int             `json:"restarts"` // Processes replaced since the agent started
+	Processes []ProcessDetail `json:"processes"`
+}
+
+// processGroup is a compiled ProcessGroupSpec with its tracking state
+type processGroup struct {
+	spec     ProcessGroupSpec
+	cmdline  *regexp.Regexp
+	members  map[int32]int64 // PID -> create time, from the previous scan
+	scanned  bool
+	restarts int
+}
+
+// ProcessGroupProbe reports stats for configurable groups of arbitrary processes
+// (php-fpm, nginx, node workers, cron daemons, ...)
+type ProcessGroupProbe struct {
+	mu     sync.Mutex
+	groups []*processGroup
+	cache  map[int32]*process.Process // Keeps CPU sampling state between scans
+}
+
+// NewProcessGroupProbe creates a probe for the given groups
+func NewProcessGroupProbe(specs []ProcessGroupSpec) (*ProcessGroupProbe, error) {
+	probe := &ProcessGroupProbe{
+		cache: make(map[int32]*process.Process),
+	}
+
+	for _, spec := range specs {
+		if spec.Name == "" {
+			return nil, fmt.Errorf("process group name is required")
+		}
+		if spec.Exe == "" && spec.Cmdline == "" && spec.User == "" && spec.Cwd == "" {
+			return nil, fmt.Errorf("process group %s: at least one matcher is required", spec.Name)
+		}
+
+		g := &processGroup{spec: spec}
+		if spec.Cmdline != "" {
+			re, err := regexp.Compile(spec.Cmdline)
+			if err != nil {
+				return nil, fmt.Errorf("process group %s: invalid cmdline pattern: %w", spec.Name, err)
+			}
+			g.cmdline = re
+		}
+		probe.groups = append(probe.groups, g)
+	}
+
+	return probe, nil
+}
+
+// GetStats scans running processes and returns stats keyed by group name
+func (p *ProcessGroupProbe) GetStats() map[string]*ProcessGroupStats {
+	allProcs, err := process.Processes()
+	if err != nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	result := make(map[string]*ProcessGroupStats, len(p.groups))
+	current := make(map[*processGroup]map[int32]int64, len(p.groups))
+	for _, g := range p.groups {
+		result[g.spec.Name] = &ProcessGroupStats{Processes: []ProcessDetail{}}
+		current[g] = make(map[int32]int64)
+	}
+
+	activePids := make(map[int32]bool)
+	now := time.Now()
+
+	for _, proc := range allProcs {
+		activePids[proc.Pid] = true
+
+		cmdline, err := proc.Cmdline()
+		if err != nil || cmdline == "" {
+			continue
+		}
+
+		for _, g := range p.groups {
+			if !g.matches(proc, cmdline) {
+				continue
+			}
+
+			// Use cached process so CPU is measured since the previous scan
+			cached, ok := p.cache[proc.Pid]
+			if !ok {
+				cached = proc
+				p.cache[proc.Pid] = cached
+			}
+
+			detail := collectProcessDetail(cached, cmdline, now)
+			stats := result[g.spec.Name]
+			stats.Count++
+			stats.CPU = round(stats.CPU+detail.CPU, 2)
+			stats.Memory += detail.Memory
+			stats.FDs += detail.FDs
+			stats.Threads += detail.Threads
+			stats.Processes = append(stats.Processes, detail)
+
+			createTime, _ := cached.CreateTime()
+			current[g][proc.Pid] = createTime
+		}
+	}
+
+	// Count restarts: a member that disappeared and was replaced by a new one
+	for _, g := range p.groups {
+		if g.scanned {
+			started, exited := 0, 0
+			for pid, created := range current[g] {
+				if prev, ok := g.members[pid]; !ok || prev != created {
+					started++
+				}
+			}
+			for pid, created := range g.members {
+				if cur, ok := current[g][pid]; !ok || cur != created {
+					exited++
+				}
+			}
+			g.restarts += min(started, exited)
+		}
+		g.members = current[g]
+		g.scanned = true
+		result[g.spec.Name].Restarts = g.restarts
+	}
+
+	// Prune cache
+	for pid := range p.cache {
+		if !activePids[pid] {
+			delete(p.cache, pid)
+		}
+	}
+
+	return result
+}
+
+// matches checks a process against every configured criterion
+func (g *processGroup) matches(proc *process.Process, cmdline string) bool {
+	if g.cmdline != nil && !g.cmdline.MatchString(cmdline) {
+		return false
+	}
+
+	if g.spec.Exe != "" {
+		exe, err := proc.Exe()
+		if err != nil || exe == "" {
+			// Exe is often unreadable for other users' processes; fall back to argv[0]
+			fields := strings.Fields(cmdline)
+			if len(fields) == 0 {
+				return false
+			}
+			exe = fields[0]
+		}
+		if ok, _ := filepath.Match(g.spec.Exe, filepath.Base(exe)); !ok {
+			name, err := proc.Name()
+			if err != nil {
+				return false
+			}
+			if ok, _ := filepath.Match(g.spec.Exe, name); !ok {
+				return false
+			}
+		}
+	}
+
+	if g.spec.User != "" {
+		user, err := proc.Username()
+		if err != nil || user != g.spec.User {
+			return false
+		}
+	}
+
+	if g.spec.Cwd != "" {
+		cwd, err := proc.Cwd()
+		if err != nil {
+			return false
+		}
+		if ok, _ := filepath.Match(g.spec.Cwd, cwd); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// collectProcessDetail gathers best-effort metrics for a single process
+func collectProcessDetail(proc *process.Process, cmdline string, now time.Time) ProcessDetail {
+	detail := ProcessDetail{
+		PID:     proc.Pid,
+		Cmdline: cmdline,
+	}
+
+	if user, err := proc.Username(); err == nil {
+		detail.User = user
+	}
+	if memInfo, err := proc.MemoryInfo(); err == nil {
+		detail.Memory = memInfo.RSS
+	}
+	// Percent(0) compares against the previous call on the same (cached) process
+	if cpuPercent, err := proc.Percent(0); err == nil {
+		detail.CPU = round(cpuPercent, 2)
+	}
+	if fds, err := proc.NumFDs(); err == nil {
+		detail.FDs = fds
+	}
+	if threads, err := proc.NumThreads(); err == nil {
+		detail.Threads = threads
+	}
+	if created, err := proc.CreateTime(); err == nil && created > 0 {
+		detail.Uptime = round(now.Sub(time.UnixMilli(created)).Seconds(), 0)
+	}
+
+	return detail
+}
@@ -0,0 +1,73 @@
+package probes
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+func TestNewProcessGroupProbeValidation(t *testing.T) {
+	if _, err := NewProcessGroupProbe([]ProcessGroupSpec{{Name: "empty"}}); err == nil {
+		t.Error("Expected error for group without matchers")
+	}
+
+	if _, err := NewProcessGroupProbe([]ProcessGroupSpec{{Name: "bad", Cmdline: "("}}); err == nil {
+		t.Error("Expected error for invalid cmdline pattern")
+	}
+
+	if _, err := NewProcessGroupProbe([]ProcessGroupSpec{{Exe: "nginx"}}); err == nil {
+		t.Error("Expected error for group without name")
+	}
+}
+
+func TestProcessGroupProbeFindsSelf(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Skip("cannot resolve test executable")
+	}
+
+	probe, err := NewProcessGroupProbe([]ProcessGroupSpec{
+		{Name: "self", Cmdline: regexp.QuoteMeta(filepath.Base(exe))},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	stats := probe.GetStats()["self"]
+	if stats == nil {
+		t.Fatal("Expected stats for group self")
+	}
+
+	found := false
+	for _, p := range stats.Processes {
+		if int(p.PID) == os.Getpid() {
+			found = true
+			if p.Memory == 0 || p.Threads == 0 {
+				t.Errorf("Expected memory and threads for own process, got %+v", p)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Expected own PID %d in group, got %+v", os.Getpid(), stats.Processes)
+	}
+
+	if stats.Restarts != 0 {
+		t.Errorf("Expected no restarts, got %d", stats.Restarts)
+	}
+}
+
+func TestProcessGroupMatchesBlankCmdline(t *testing.T) {
+	probe, err := NewProcessGroupProbe([]ProcessGroupSpec{{Name: "nginx", Exe: "nginx"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A process that is gone has no readable exe, so argv[0] is used
+	gone := &process.Process{Pid: -1}
+	if probe.groups[0].matches(gone, "  \t ") {
+		t.Error("Expected whitespace-only cmdline not to match")
+	}
+}
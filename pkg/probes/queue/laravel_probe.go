@@ -2,7 +2,9 @@ package queue
 
 import (
 	"context"
+	"log/slog"
 
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
 	"github.com/gravito-framework/quasar-go/pkg/probes"
 	"github.com/gravito-framework/quasar-go/pkg/types"
 	"github.com/redis/go-redis/v9"
@@ -13,10 +15,17 @@ import (
 //   - Waiting: queues:{name} (List)
 //   - Delayed: queues:{name}:delayed (ZSet)
 //   - Reserved (Active): queues:{name}:reserved (ZSet)
+//   - Paused: {cachePrefix}illuminate:queue:paused:{connection}:{name} (cache flag)
 type LaravelProbe struct {
 	client *redis.Client
 	name   string
 	prefix string
+
+	// Pause detection (optional, see WithPauseFlag)
+	cachePrefix string
+	connection  string
+	horizon     *sharedRead[[]laravel.HorizonSupervisor] // Shared by probes of the same Horizon
+	cacheClient *redis.Client                            // Cache database, when it differs from the queue's
 }
 
 // NewLaravelProbe creates a probe for Laravel Queue
@@ -37,6 +46,26 @@ func NewLaravelProbeWithPrefix(client *redis.Client, queueName, prefix string) *
 	}
 }
 
+// WithPauseFlag enables pause detection via the Laravel cache flag and,
+// when horizonPrefix is set, paused Horizon supervisors
+func (p *LaravelProbe) WithPauseFlag(cachePrefix, connection, horizonPrefix string) *LaravelProbe {
+	p.cachePrefix = cachePrefix
+	p.connection = connection
+	if horizonPrefix != "" {
+		p.horizon = horizonSupervisors(p.client, horizonPrefix)
+	}
+	return p
+}
+
+// WithCacheClient reads pause flags from another client, for apps whose
+// cache store uses a different Redis database than the queue
+func (p *LaravelProbe) WithCacheClient(client *redis.Client) *LaravelProbe {
+	if client != p.client {
+		p.cacheClient = client
+	}
+	return p
+}
+
 // GetSnapshot returns current Laravel queue state
 func (p *LaravelProbe) GetSnapshot() (*types.QueueSnapshot, error) {
 	ctx := context.Background()
@@ -51,11 +80,28 @@ func (p *LaravelProbe) GetSnapshot() (*types.QueueSnapshot, error) {
 	waitingCmd := pipe.LLen(ctx, keyWaiting)
 	delayedCmd := pipe.ZCard(ctx, keyDelayed)
 	reservedCmd := pipe.ZCard(ctx, keyReserved)
+	var pausedCmd *redis.StringCmd
+	if p.connection != "" && p.cacheClient == nil {
+		pausedCmd = pipe.Get(ctx, laravel.PauseKey(p.cachePrefix, p.connection, p.name))
+	}
 
 	_, err := pipe.Exec(ctx)
 	if err != nil && err != redis.Nil {
 		return nil, err
 	}
+	if p.connection != "" && p.cacheClient != nil {
+		pausedCmd = p.cacheClient.Get(ctx, laravel.PauseKey(p.cachePrefix, p.connection, p.name))
+	}
+
+	paused := pausedCmd != nil && pausedCmd.Err() == nil && laravel.PauseFlagSet(pausedCmd.Val())
+	if !paused && p.connection != "" && p.horizon != nil {
+		// Pause state is secondary; don't lose the queue sizes over it
+		supervisors, err := p.horizon.get(ctx)
+		if err != nil {
+			slog.Warn("Failed to read Horizon supervisors", "queue", p.name, "error", err)
+		}
+		paused = laravel.HorizonQueuePaused(supervisors, p.connection, p.name)
+	}
 
 	// Note: Standard Laravel (without Horizon) stores failed jobs in Database (MySQL),
 	// so we return 0 for failed unless using Horizon mode.
@@ -68,6 +114,7 @@ func (p *LaravelProbe) GetSnapshot() (*types.QueueSnapshot, error) {
 			Failed:  0,                 // Cannot read from Redis easily in standard setup
 			Delayed: delayedCmd.Val(),
 		},
+		Paused: paused,
 	}, nil
 }
 
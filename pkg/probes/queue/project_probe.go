@@ -0,0 +1,31 @@
+package queue
+
+import (
+	"github.com/gravito-framework/quasar-go/pkg/probes"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+)
+
+// ProjectProbe tags the snapshots of another probe with a Laravel project,
+// so queues with the same name in different projects stay apart
+type ProjectProbe struct {
+	probe   probes.QueueProbe
+	project string
+}
+
+// NewProjectProbe wraps a probe for the given project
+func NewProjectProbe(project string, probe probes.QueueProbe) *ProjectProbe {
+	return &ProjectProbe{probe: probe, project: project}
+}
+
+// GetSnapshot returns the wrapped probe's snapshot with the project set
+func (p *ProjectProbe) GetSnapshot() (*types.QueueSnapshot, error) {
+	snapshot, err := p.probe.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Project = p.project
+	return snapshot, nil
+}
+
+// Ensure ProjectProbe implements QueueProbe
+var _ probes.QueueProbe = (*ProjectProbe)(nil)
@@ -0,0 +1,113 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/laravel"
+	"github.com/gravito-framework/quasar-go/pkg/sidekiq"
+	"github.com/redis/go-redis/v9"
+)
+
+// sharedReadTTL is how long a shared read serves other probes. It is shorter
+// than the minimum heartbeat interval, so each heartbeat reads once.
+const sharedReadTTL = 500 * time.Millisecond
+
+// sharedRead caches a value that several probes need, e.g. state that
+// is not per queue
+type sharedRead[T any] struct {
+	mu     sync.Mutex
+	read   func(ctx context.Context) (T, error)
+	readAt time.Time
+	value  T
+	err    error
+}
+
+// get returns the cached value, reading it again once it is older than sharedReadTTL
+func (s *sharedRead[T]) get(ctx context.Context) (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.readAt.IsZero() || time.Since(s.readAt) >= sharedReadTTL {
+		s.value, s.err = s.read(ctx)
+		s.readAt = time.Now()
+	}
+	return s.value, s.err
+}
+
+// sharedKey identifies a shared read by Redis client and key prefix
+type sharedKey struct {
+	client *redis.Client
+	prefix string
+}
+
+var (
+	sharedMu     sync.Mutex
+	horizonReads = make(map[sharedKey]*sharedRead[[]laravel.HorizonSupervisor])
+	sidekiqReads = make(map[sharedKey]*sharedRead[*sidekiqState])
+)
+
+// horizonSupervisors returns the shared read of Horizon's supervisors
+func horizonSupervisors(client *redis.Client, prefix string) *sharedRead[[]laravel.HorizonSupervisor] {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	key := sharedKey{client, prefix}
+	if r, ok := horizonReads[key]; ok {
+		return r
+	}
+	r := &sharedRead[[]laravel.HorizonSupervisor]{
+		read: func(ctx context.Context) ([]laravel.HorizonSupervisor, error) {
+			return laravel.HorizonSupervisors(ctx, client, prefix)
+		},
+	}
+	horizonReads[key] = r
+	return r
+}
+
+// sidekiqState is the state of a Sidekiq namespace that is not per queue
+type sidekiqState struct {
+	sets      map[string]map[string]int64 // Jobs per queue in the schedule, retry and dead sets
+	busy      map[string]int64            // Running jobs per queue
+	processed int64
+	failed    int64
+}
+
+// sidekiqStates returns the shared read of a Sidekiq namespace's state
+func sidekiqStates(client *redis.Client, ns sidekiq.Namespace) *sharedRead[*sidekiqState] {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	key := sharedKey{client, string(ns)}
+	if r, ok := sidekiqReads[key]; ok {
+		return r
+	}
+	r := &sharedRead[*sidekiqState]{
+		read: func(ctx context.Context) (*sidekiqState, error) {
+			return readSidekiqState(ctx, client, ns)
+		},
+	}
+	sidekiqReads[key] = r
+	return r
+}
+
+func readSidekiqState(ctx context.Context, client *redis.Client, ns sidekiq.Namespace) (*sidekiqState, error) {
+	state := &sidekiqState{sets: make(map[string]map[string]int64)}
+	for _, set := range []string{sidekiq.SetSchedule, sidekiq.SetRetry, sidekiq.SetDead} {
+		counts, err := sidekiq.CountByQueue(ctx, client, ns.Key(set))
+		if err != nil {
+			return nil, err
+		}
+		state.sets[set] = counts
+	}
+
+	var err error
+	if state.busy, err = sidekiq.Busy(ctx, client, ns); err != nil {
+		return nil, err
+	}
+	if state.processed, state.failed, err = sidekiq.Stats(ctx, client, ns); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
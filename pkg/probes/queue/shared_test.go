@@ -0,0 +1,44 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSharedReadCachesOneRead(t *testing.T) {
+	reads := 0
+	s := &sharedRead[int]{read: func(context.Context) (int, error) {
+		reads++
+		if reads == 1 {
+			return 0, errors.New("connection refused")
+		}
+		return reads, nil
+	}}
+
+	// Probes in the same heartbeat share the result, errors included
+	for i := 0; i < 3; i++ {
+		if _, err := s.get(context.Background()); err == nil {
+			t.Fatal("Expected the first read's error")
+		}
+	}
+	if reads != 1 {
+		t.Fatalf("Expected 1 read, got %d", reads)
+	}
+
+	// The next heartbeat reads again
+	s.readAt = time.Now().Add(-sharedReadTTL)
+	if v, err := s.get(context.Background()); err != nil || v != 2 {
+		t.Errorf("Expected a fresh value 2, got %d, %v", v, err)
+	}
+}
+
+func TestHorizonSupervisorsShared(t *testing.T) {
+	if horizonSupervisors(nil, "horizon:") != horizonSupervisors(nil, "horizon:") {
+		t.Error("Expected probes of the same Horizon to share a read")
+	}
+	if horizonSupervisors(nil, "horizon:") == horizonSupervisors(nil, "shop_horizon:") {
+		t.Error("Expected separate reads per Horizon prefix")
+	}
+}
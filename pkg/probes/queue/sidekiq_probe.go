@@ -0,0 +1,100 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/probes"
+	"github.com/gravito-framework/quasar-go/pkg/sidekiq"
+	"github.com/gravito-framework/quasar-go/pkg/types"
+	"github.com/redis/go-redis/v9"
+)
+
+// SidekiqProbe monitors a Sidekiq queue
+// Sidekiq uses these key patterns (optionally under a namespace):
+//   - Waiting: queue:{name} (List)
+//   - Scheduled, retry, dead: schedule, retry, dead (ZSets shared by all queues)
+//   - Busy: {identity}:work hashes of the processes set
+//   - Counters: stat:processed, stat:failed
+type SidekiqProbe struct {
+	client    *redis.Client
+	name      string
+	namespace sidekiq.Namespace
+	shared    *sharedRead[*sidekiqState] // Shared by probes of the same namespace
+
+	mu   sync.Mutex
+	last *statsSample // Previous counters, for per-minute rates
+}
+
+type statsSample struct {
+	at        time.Time
+	processed int64
+	failed    int64
+}
+
+// NewSidekiqProbe creates a probe for a Sidekiq queue
+func NewSidekiqProbe(client *redis.Client, queueName, namespace string) *SidekiqProbe {
+	ns := sidekiq.Namespace(namespace)
+	return &SidekiqProbe{
+		client:    client,
+		name:      queueName,
+		namespace: ns,
+		shared:    sidekiqStates(client, ns),
+	}
+}
+
+// GetSnapshot returns current Sidekiq queue state
+func (p *SidekiqProbe) GetSnapshot() (*types.QueueSnapshot, error) {
+	ctx := context.Background()
+
+	waiting, err := p.client.LLen(ctx, p.namespace.Queue(p.name)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	// The job sets, processes and counters are shared by all queues and
+	// read once for them
+	state, err := p.shared.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int64)
+	for set, byQueue := range state.sets {
+		counts[set] = byQueue[p.name]
+	}
+
+	return &types.QueueSnapshot{
+		Name:   p.name,
+		Driver: types.DriverSidekiq,
+		Size: types.QueueSize{
+			Waiting: waiting,
+			Active:  state.busy[p.name],
+			Failed:  counts[sidekiq.SetRetry] + counts[sidekiq.SetDead],
+			Delayed: counts[sidekiq.SetSchedule],
+			Retry:   counts[sidekiq.SetRetry],
+			Dead:    counts[sidekiq.SetDead],
+		},
+		Stats: p.stats(state.processed, state.failed, time.Now()),
+	}, nil
+}
+
+// stats turns the lifetime counters into per-minute rates since the last
+// snapshot (0 on the first one or after a counter reset)
+func (p *SidekiqProbe) stats(processed, failed int64, now time.Time) *types.QueueStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := &types.QueueStats{Processed: processed, Failed: failed}
+	if last := p.last; last != nil && processed >= last.processed && failed >= last.failed {
+		if minutes := now.Sub(last.at).Minutes(); minutes > 0 {
+			stats.ProcessedPerMin = float64(processed-last.processed) / minutes
+			stats.FailedPerMin = float64(failed-last.failed) / minutes
+		}
+	}
+	p.last = &statsSample{at: now, processed: processed, failed: failed}
+	return stats
+}
+
+// Ensure SidekiqProbe implements QueueProbe
+var _ probes.QueueProbe = (*SidekiqProbe)(nil)
@@ -0,0 +1,48 @@
+package queue
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestSidekiqProbesShareNamespaceReads(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	server.Lpush("myapp:queue:default", "{}")
+	server.ZAdd("myapp:retry", 1, `{"jid":"a","queue":"default"}`)
+	server.ZAdd("myapp:retry", 2, `{"jid":"b","queue":"critical"}`)
+	server.ZAdd("myapp:dead", 1, `{"jid":"c","queue":"critical"}`)
+	server.ZAdd("myapp:schedule", 1, `{"jid":"d","queue":"default"}`)
+	server.Set("myapp:stat:processed", "10")
+
+	defaultProbe := NewSidekiqProbe(client, "default", "myapp")
+	criticalProbe := NewSidekiqProbe(client, "critical", "myapp")
+	if defaultProbe.shared != criticalProbe.shared {
+		t.Fatal("Expected queues of one namespace to share a read")
+	}
+	if NewSidekiqProbe(client, "default", "other").shared == defaultProbe.shared {
+		t.Error("Expected separate reads per namespace")
+	}
+
+	snapshot, err := defaultProbe.GetSnapshot()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if snapshot.Size.Waiting != 1 || snapshot.Size.Retry != 1 || snapshot.Size.Dead != 0 || snapshot.Size.Delayed != 1 {
+		t.Errorf("Unexpected default queue size: %+v", snapshot.Size)
+	}
+
+	// Sets changed after the shared read are not re-scanned in the same heartbeat
+	server.ZAdd("myapp:dead", 2, `{"jid":"e","queue":"critical"}`)
+	snapshot, err = criticalProbe.GetSnapshot()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if snapshot.Size.Retry != 1 || snapshot.Size.Dead != 1 || snapshot.Stats.Processed != 10 {
+		t.Errorf("Unexpected critical queue snapshot: %+v %+v", snapshot.Size, snapshot.Stats)
+	}
+}
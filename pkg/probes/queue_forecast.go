@@ -0,0 +1,136 @@
+package probes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/types"
+)
+
+// backlogSample is one observation of a queue's waiting size
+type backlogSample struct {
+	at      time.Time
+	waiting int64
+	net     *float64 // Reported throughput in - out (jobs/min), if any
+}
+
+// QueueForecaster derives growth rate and drain time from successive snapshots
+type QueueForecaster struct {
+	mu      sync.Mutex
+	window  time.Duration
+	samples map[string][]backlogSample
+}
+
+// NewQueueForecaster creates a forecaster smoothing over the given window
+func NewQueueForecaster(window time.Duration) *QueueForecaster {
+	return &QueueForecaster{
+		window:  window,
+		samples: make(map[string][]backlogSample),
+	}
+}
+
+// Observe records the snapshot and sets its Forecast once two samples exist
+func (f *QueueForecaster) Observe(snapshot *types.QueueSnapshot, now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sample := backlogSample{at: now, waiting: snapshot.Size.Waiting}
+	if t := snapshot.Throughput; t != nil {
+		net := t.In - t.Out
+		sample.net = &net
+	}
+
+	key := forecastKey(snapshot)
+	samples := append(f.samples[key], sample)
+
+	cutoff := now.Add(-f.window)
+	for len(samples) > 2 && samples[0].at.Before(cutoff) {
+		samples = samples[1:]
+	}
+	f.samples[key] = samples
+
+	if len(samples) < 2 {
+		return
+	}
+	snapshot.Forecast = forecast(samples)
+}
+
+// Retain forgets queues that are not among the current snapshots
+func (f *QueueForecaster) Retain(snapshots []types.QueueSnapshot) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	seen := make(map[string]bool, len(snapshots))
+	for i := range snapshots {
+		seen[forecastKey(&snapshots[i])] = true
+	}
+	for key := range f.samples {
+		if !seen[key] {
+			delete(f.samples, key)
+		}
+	}
+}
+
+func forecastKey(snapshot *types.QueueSnapshot) string {
+	return snapshot.Project + ":" + string(snapshot.Driver) + ":" + snapshot.Name
+}
+
+// forecast computes the forecast for samples ordered by time
+func forecast(samples []backlogSample) *types.QueueForecast {
+	// Prefer reported throughput when every sample has it, otherwise
+	// fall back to the trend of the waiting size
+	perMin, reported := meanNetThroughput(samples)
+	if !reported {
+		perMin = backlogSlope(samples) * 60
+	}
+
+	waiting := samples[len(samples)-1].waiting
+	result := &types.QueueForecast{
+		GrowthRate: perMin,
+		Growing:    waiting > 0 && perMin > 0,
+	}
+
+	switch {
+	case waiting == 0:
+		zero := 0.0
+		result.DrainSeconds = &zero
+	case perMin < 0:
+		eta := float64(waiting) / -perMin * 60
+		result.DrainSeconds = &eta
+	}
+
+	return result
+}
+
+func meanNetThroughput(samples []backlogSample) (float64, bool) {
+	var sum float64
+	for _, s := range samples {
+		if s.net == nil {
+			return 0, false
+		}
+		sum += *s.net
+	}
+	return sum / float64(len(samples)), true
+}
+
+// backlogSlope returns the least-squares change of the waiting size in jobs per second
+func backlogSlope(samples []backlogSample) float64 {
+	n := float64(len(samples))
+	origin := samples[0].at
+
+	var sumX, sumY, sumXY, sumXX float64
+	for _, s := range samples {
+		x := s.at.Sub(origin).Seconds()
+		y := float64(s.waiting)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denom
+}
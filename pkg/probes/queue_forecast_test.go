@@ -0,0 +1,120 @@
+package probes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/types"
+)
+
+func TestQueueForecaster(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name        string
+		waiting     []int64
+		throughput  *types.QueueThroughput
+		growthRate  float64
+		growing     bool
+		drain       float64
+		hasDrainETA bool
+	}{
+		{
+			name:        "draining 100 jobs/min",
+			waiting:     []int64{1000, 900, 800},
+			growthRate:  -100,
+			drain:       480,
+			hasDrainETA: true,
+		},
+		{
+			name:       "growing",
+			waiting:    []int64{100, 150, 200},
+			growthRate: 50,
+			growing:    true,
+		},
+		{
+			name:        "empty",
+			waiting:     []int64{0, 0, 0},
+			drain:       0,
+			hasDrainETA: true,
+		},
+		{
+			name:        "reported throughput wins",
+			waiting:     []int64{600, 600, 600},
+			throughput:  &types.QueueThroughput{In: 10, Out: 40},
+			growthRate:  -30,
+			drain:       1200,
+			hasDrainETA: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewQueueForecaster(5 * time.Minute)
+
+			var snap types.QueueSnapshot
+			for i, waiting := range tt.waiting {
+				snap = types.QueueSnapshot{Name: "default", Driver: types.DriverRedis, Throughput: tt.throughput}
+				snap.Size.Waiting = waiting
+				f.Observe(&snap, start.Add(time.Duration(i)*time.Minute))
+			}
+
+			fc := snap.Forecast
+			if fc == nil {
+				t.Fatal("Expected a forecast")
+			}
+			if fc.GrowthRate != tt.growthRate {
+				t.Errorf("Expected growth rate %v, got %v", tt.growthRate, fc.GrowthRate)
+			}
+			if fc.Growing != tt.growing {
+				t.Errorf("Expected growing %v, got %v", tt.growing, fc.Growing)
+			}
+			if (fc.DrainSeconds != nil) != tt.hasDrainETA {
+				t.Fatalf("Expected drain ETA present=%v, got %v", tt.hasDrainETA, fc.DrainSeconds)
+			}
+			if fc.DrainSeconds != nil && *fc.DrainSeconds != tt.drain {
+				t.Errorf("Expected drain %vs, got %v", tt.drain, *fc.DrainSeconds)
+			}
+		})
+	}
+}
+
+func TestQueueForecasterWindow(t *testing.T) {
+	f := NewQueueForecaster(2 * time.Minute)
+	start := time.Unix(1700000000, 0)
+
+	// An old spike falls out of the window and no longer skews the trend
+	sizes := []int64{5000, 100, 100, 100, 100}
+	var snap types.QueueSnapshot
+	for i, waiting := range sizes {
+		snap = types.QueueSnapshot{Name: "default"}
+		snap.Size.Waiting = waiting
+		f.Observe(&snap, start.Add(time.Duration(i)*time.Minute))
+
+		if i == 0 && snap.Forecast != nil {
+			t.Error("Expected no forecast from a single sample")
+		}
+	}
+
+	if snap.Forecast.GrowthRate != 0 {
+		t.Errorf("Expected flat growth after the spike left the window, got %v", snap.Forecast.GrowthRate)
+	}
+}
+
+func TestQueueForecasterRetain(t *testing.T) {
+	f := NewQueueForecaster(time.Minute)
+	now := time.Unix(1700000000, 0)
+
+	current := []types.QueueSnapshot{{Name: "default"}, {Name: "emails"}}
+	for i := range current {
+		f.Observe(&current[i], now)
+	}
+
+	f.Retain(current[:1])
+	if len(f.samples) != 1 {
+		t.Fatalf("Expected only default to be kept, got %d queues", len(f.samples))
+	}
+	if _, ok := f.samples[forecastKey(&current[0])]; !ok {
+		t.Error("Expected samples of default to be kept")
+	}
+}
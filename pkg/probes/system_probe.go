@@ -5,13 +5,17 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/gravito-framework/quasar-go/pkg/types"
 	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
 	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/shirou/gopsutil/v3/net"
 	"github.com/shirou/gopsutil/v3/process"
 )
 
@@ -23,24 +27,70 @@ type GoSystemProbe struct {
 	// CPU sampling
 	mu               sync.RWMutex
 	lastCPUTimes     cpu.TimesStat
+	lastCoreTimes    map[string]cpu.TimesStat
 	lastSampleTime   time.Time
 	cachedCPUPercent float64
+	cachedBreakdown  *types.CPUBreakdown
+	cachedPerCore    []types.CPUCoreMetrics
+	sampleInterval   time.Duration
+	perCore          bool
 	stopSampler      chan struct{}
 	isDarwin         bool
+
+	// Disk and network sampling (rates are computed between GetMetrics calls)
+	diskInclude  []string
+	diskExclude  []string
+	ioMu         sync.Mutex
+	lastDiskIO   map[string]disk.IOCountersStat
+	lastNetIO    map[string]net.IOCountersStat
+	lastIOSample time.Time
+}
+
+// SystemProbeOption is a functional option for configuring the GoSystemProbe
+type SystemProbeOption func(*GoSystemProbe)
+
+// WithDiskFilter restricts reported mountpoints. Patterns use path.Match syntax
+// (e.g. "/", "/var/*"). An empty include list reports every physical mountpoint.
+func WithDiskFilter(include, exclude []string) SystemProbeOption {
+	return func(p *GoSystemProbe) {
+		p.diskInclude = include
+		p.diskExclude = exclude
+	}
+}
+
+// WithCPUSampleInterval sets the background CPU sampling window (default: 1s)
+func WithCPUSampleInterval(d time.Duration) SystemProbeOption {
+	return func(p *GoSystemProbe) {
+		if d > 0 {
+			p.sampleInterval = d
+		}
+	}
+}
+
+// WithPerCoreCPU enables per-core CPU figures in CPUMetrics
+func WithPerCoreCPU(enabled bool) SystemProbeOption {
+	return func(p *GoSystemProbe) {
+		p.perCore = enabled
+	}
 }
 
 // NewGoSystemProbe creates a new system probe for Go processes
-func NewGoSystemProbe() (*GoSystemProbe, error) {
+func NewGoSystemProbe(opts ...SystemProbeOption) (*GoSystemProbe, error) {
 	p, err := process.NewProcess(int32(os.Getpid()))
 	if err != nil {
 		return nil, err
 	}
 
 	probe := &GoSystemProbe{
-		startTime:   time.Now(),
-		proc:        p,
-		stopSampler: make(chan struct{}),
-		isDarwin:    runtime.GOOS == "darwin",
+		startTime:      time.Now(),
+		proc:           p,
+		sampleInterval: 1 * time.Second,
+		stopSampler:    make(chan struct{}),
+		isDarwin:       runtime.GOOS == "darwin",
+	}
+
+	for _, opt := range opts {
+		opt(probe)
 	}
 
 	// Initialize CPU baseline
@@ -49,20 +99,23 @@ func NewGoSystemProbe() (*GoSystemProbe, error) {
 		probe.lastCPUTimes = times[0]
 		probe.lastSampleTime = time.Now()
 	}
+	if probe.perCore {
+		probe.lastCoreTimes = sampleCoreTimes()
+	}
 
-	// Wait 1 second and take first sample to initialize cachedCPUPercent
-	time.Sleep(1 * time.Second)
+	// Wait one sampling window and take first sample to initialize cachedCPUPercent
+	time.Sleep(probe.sampleInterval)
 	probe.sampleCPU()
 
-	// Start background CPU sampler (samples every 1 second)
+	// Start background CPU sampler
 	go probe.cpuSampler()
 
 	return probe, nil
 }
 
-// cpuSampler runs in background to sample CPU usage every second
+// cpuSampler runs in background to sample CPU usage every sampling window
 func (p *GoSystemProbe) cpuSampler() {
-	ticker := time.NewTicker(1 * time.Second)
+	ticker := time.NewTicker(p.sampleInterval)
 	defer ticker.Stop()
 
 	for {
@@ -79,6 +132,11 @@ func (p *GoSystemProbe) cpuSampler() {
 func (p *GoSystemProbe) sampleCPU() {
 	times, err := cpu.Times(false)
 
+	var coreTimes map[string]cpu.TimesStat
+	if p.perCore {
+		coreTimes = sampleCoreTimes()
+	}
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -86,12 +144,9 @@ func (p *GoSystemProbe) sampleCPU() {
 		current := times[0]
 		now := time.Now()
 
-		deltaTotal := (current.User + current.System + current.Idle + current.Nice + current.Iowait + current.Irq + current.Softirq + current.Steal) -
-			(p.lastCPUTimes.User + p.lastCPUTimes.System + p.lastCPUTimes.Idle + p.lastCPUTimes.Nice + p.lastCPUTimes.Iowait + p.lastCPUTimes.Irq + p.lastCPUTimes.Softirq + p.lastCPUTimes.Steal)
-		deltaIdle := current.Idle - p.lastCPUTimes.Idle
-
-		if deltaTotal > 0 {
-			p.cachedCPUPercent = round(100*(deltaTotal-deltaIdle)/deltaTotal, 2)
+		if busy, breakdown, ok := cpuDelta(p.lastCPUTimes, current); ok {
+			p.cachedCPUPercent = busy
+			p.cachedBreakdown = &breakdown
 		}
 
 		p.lastCPUTimes = current
@@ -102,6 +157,88 @@ func (p *GoSystemProbe) sampleCPU() {
 			p.cachedCPUPercent = val
 		}
 	}
+
+	if coreTimes != nil {
+		perCore := make([]types.CPUCoreMetrics, 0, len(coreTimes))
+		for name, current := range coreTimes {
+			last, ok := p.lastCoreTimes[name]
+			if !ok {
+				continue
+			}
+			if busy, breakdown, ok := cpuDelta(last, current); ok {
+				perCore = append(perCore, types.CPUCoreMetrics{
+					Core:      name,
+					Usage:     busy,
+					Breakdown: breakdown,
+				})
+			}
+		}
+		sort.Slice(perCore, func(i, j int) bool {
+			return coreIndex(perCore[i].Core) < coreIndex(perCore[j].Core)
+		})
+		p.cachedPerCore = perCore
+		p.lastCoreTimes = coreTimes
+	}
+}
+
+// sampleCoreTimes returns per-core CPU times keyed by core name, or nil on failure
+func sampleCoreTimes() map[string]cpu.TimesStat {
+	times, err := cpu.Times(true)
+	if err != nil {
+		return nil
+	}
+	result := make(map[string]cpu.TimesStat, len(times))
+	for _, t := range times {
+		result[t.CPU] = t
+	}
+	return result
+}
+
+// coreIndex extracts the numeric suffix of a core name ("cpu12" -> 12) for ordering
+func coreIndex(name string) int {
+	n, err := strconv.Atoi(strings.TrimPrefix(name, "cpu"))
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// cpuDelta computes busy % and a per-state breakdown between two samples.
+// Busy time is everything except idle (iowait counts as busy, as before).
+func cpuDelta(last, current cpu.TimesStat) (float64, types.CPUBreakdown, bool) {
+	deltaTotal := cpuTotal(current) - cpuTotal(last)
+	if deltaTotal <= 0 {
+		return 0, types.CPUBreakdown{}, false
+	}
+
+	pct := func(cur, prev float64) float64 {
+		d := cur - prev
+		if d < 0 {
+			return 0
+		}
+		return round(100*d/deltaTotal, 2)
+	}
+
+	breakdown := types.CPUBreakdown{
+		User:    pct(current.User, last.User),
+		Nice:    pct(current.Nice, last.Nice),
+		System:  pct(current.System, last.System),
+		Idle:    pct(current.Idle, last.Idle),
+		Iowait:  pct(current.Iowait, last.Iowait),
+		Irq:     pct(current.Irq, last.Irq),
+		Softirq: pct(current.Softirq, last.Softirq),
+		Steal:   pct(current.Steal, last.Steal),
+	}
+
+	deltaIdle := current.Idle - last.Idle
+	busy := round(100*(deltaTotal-deltaIdle)/deltaTotal, 2)
+
+	return busy, breakdown, true
+}
+
+// cpuTotal sums every CPU state counted towards total time
+func cpuTotal(t cpu.TimesStat) float64 {
+	return t.User + t.System + t.Idle + t.Nice + t.Iowait + t.Irq + t.Softirq + t.Steal
 }
 
 // getDarwinSystemCPU parses 'top' output on macOS
@@ -163,6 +300,9 @@ func (p *GoSystemProbe) GetMetrics() (*SystemMetrics, error) {
 		return nil, err
 	}
 
+	// Disk, IO and network metrics are best-effort
+	diskIO, network := p.getIORates()
+
 	return &SystemMetrics{
 		Language: types.LangGo,
 		Version:  runtime.Version(),
@@ -172,6 +312,10 @@ func (p *GoSystemProbe) GetMetrics() (*SystemMetrics, error) {
 		Uptime:   time.Since(p.startTime).Seconds(),
 		CPU:      *cpuMetrics,
 		Memory:   *memMetrics,
+		Disks:    p.getDiskMetrics(),
+		DiskIO:   diskIO,
+		Network:  network,
+		Load:     getLoadAverage(),
 	}, nil
 }
 
@@ -179,6 +323,8 @@ func (p *GoSystemProbe) getCPUMetrics() (*types.CPUMetrics, error) {
 	// System CPU: Use cached value from background sampler
 	p.mu.RLock()
 	systemPercent := p.cachedCPUPercent
+	breakdown := p.cachedBreakdown
+	perCore := p.cachedPerCore
 	p.mu.RUnlock()
 
 	// Core count - fallback to runtime.NumCPU()
@@ -196,9 +342,11 @@ func (p *GoSystemProbe) getCPUMetrics() (*types.CPUMetrics, error) {
 	}
 
 	return &types.CPUMetrics{
-		System:  systemPercent,
-		Process: procPercent,
-		Cores:   cores,
+		System:    systemPercent,
+		Process:   procPercent,
+		Cores:     cores,
+		Breakdown: breakdown,
+		PerCore:   perCore,
 	}, nil
 }
 
@@ -245,8 +393,20 @@ func (p *GoSystemProbe) getMemoryMetrics() (*types.MemoryMetrics, error) {
 		processMem.HeapUsed = m.HeapAlloc
 	}
 
+	// Swap is optional (not available on every platform)
+	var swapMem *types.SwapMemory
+	if s, err := mem.SwapMemory(); err == nil {
+		swapMem = &types.SwapMemory{
+			Total:       s.Total,
+			Used:        s.Used,
+			Free:        s.Free,
+			UsedPercent: round(s.UsedPercent, 2),
+		}
+	}
+
 	return &types.MemoryMetrics{
 		System:  systemMem,
 		Process: processMem,
+		Swap:    swapMem,
 	}, nil
 }
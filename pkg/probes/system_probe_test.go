@@ -0,0 +1,44 @@
+package probes
+
+import (
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+)
+
+func TestCPUDelta(t *testing.T) {
+	last := cpu.TimesStat{User: 100, System: 50, Idle: 800, Iowait: 30, Steal: 20}
+	current := cpu.TimesStat{User: 120, System: 60, Idle: 840, Iowait: 50, Steal: 30}
+
+	busy, breakdown, ok := cpuDelta(last, current)
+	if !ok {
+		t.Fatal("Expected delta to be computed")
+	}
+
+	// Total delta = 20 + 10 + 40 + 20 + 10 = 100
+	if busy != 60 {
+		t.Errorf("Expected busy 60%%, got %v", busy)
+	}
+	if breakdown.Iowait != 20 {
+		t.Errorf("Expected iowait 20%%, got %v", breakdown.Iowait)
+	}
+	if breakdown.Steal != 10 {
+		t.Errorf("Expected steal 10%%, got %v", breakdown.Steal)
+	}
+	if breakdown.Idle != 40 {
+		t.Errorf("Expected idle 40%%, got %v", breakdown.Idle)
+	}
+
+	if _, _, ok := cpuDelta(current, current); ok {
+		t.Error("Expected no delta for identical samples")
+	}
+}
+
+func TestCoreIndex(t *testing.T) {
+	if coreIndex("cpu12") != 12 {
+		t.Errorf("Expected 12, got %d", coreIndex("cpu12"))
+	}
+	if coreIndex("cpu-total") != -1 {
+		t.Errorf("Expected -1 for non-numeric core")
+	}
+}
@@ -0,0 +1,157 @@
+package probes
+
+import (
+	"path"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/types"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+// getDiskMetrics returns usage for every physical mountpoint that passes the filters
+func (p *GoSystemProbe) getDiskMetrics() []types.DiskMetrics {
+	// Physical devices only (skips tmpfs, proc, cgroup, etc.)
+	partitions, err := disk.Partitions(false)
+	if err != nil {
+		return nil
+	}
+
+	var disks []types.DiskMetrics
+	seen := make(map[string]bool)
+
+	for _, part := range partitions {
+		if seen[part.Mountpoint] || !matchMountpoint(part.Mountpoint, p.diskInclude, p.diskExclude) {
+			continue
+		}
+		seen[part.Mountpoint] = true
+
+		usage, err := disk.Usage(part.Mountpoint)
+		if err != nil || usage.Total == 0 {
+			continue
+		}
+
+		disks = append(disks, types.DiskMetrics{
+			Mountpoint:        part.Mountpoint,
+			Device:            part.Device,
+			Fstype:            part.Fstype,
+			Total:             usage.Total,
+			Free:              usage.Free,
+			Used:              usage.Used,
+			UsedPercent:       round(usage.UsedPercent, 2),
+			InodesTotal:       usage.InodesTotal,
+			InodesUsed:        usage.InodesUsed,
+			InodesUsedPercent: round(usage.InodesUsedPercent, 2),
+		})
+	}
+
+	return disks
+}
+
+// matchMountpoint applies include/exclude glob patterns to a mountpoint.
+// Exclusions win over inclusions.
+func matchMountpoint(mountpoint string, include, exclude []string) bool {
+	for _, pattern := range exclude {
+		if ok, _ := path.Match(pattern, mountpoint); ok {
+			return false
+		}
+	}
+
+	if len(include) == 0 {
+		return true
+	}
+
+	for _, pattern := range include {
+		if ok, _ := path.Match(pattern, mountpoint); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getIORates computes disk and network rates since the previous call.
+// The first call only records a baseline and returns no rates.
+func (p *GoSystemProbe) getIORates() ([]types.DiskIOMetrics, []types.NetworkMetrics) {
+	diskCounters, diskErr := disk.IOCounters()
+	netCounters, netErr := net.IOCounters(true)
+
+	p.ioMu.Lock()
+	defer p.ioMu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(p.lastIOSample).Seconds()
+	hasBaseline := !p.lastIOSample.IsZero() && elapsed > 0
+
+	var diskIO []types.DiskIOMetrics
+	if diskErr == nil {
+		if hasBaseline {
+			for name, current := range diskCounters {
+				last, ok := p.lastDiskIO[name]
+				if !ok {
+					continue
+				}
+				diskIO = append(diskIO, types.DiskIOMetrics{
+					Device:           name,
+					ReadBytesPerSec:  rate(current.ReadBytes, last.ReadBytes, elapsed),
+					WriteBytesPerSec: rate(current.WriteBytes, last.WriteBytes, elapsed),
+					ReadOpsPerSec:    rate(current.ReadCount, last.ReadCount, elapsed),
+					WriteOpsPerSec:   rate(current.WriteCount, last.WriteCount, elapsed),
+				})
+			}
+		}
+		p.lastDiskIO = diskCounters
+	}
+
+	var network []types.NetworkMetrics
+	if netErr == nil {
+		current := make(map[string]net.IOCountersStat, len(netCounters))
+		for _, c := range netCounters {
+			// Loopback traffic is never what saturates a NIC
+			if c.Name == "lo" || c.Name == "lo0" {
+				continue
+			}
+			current[c.Name] = c
+
+			last, ok := p.lastNetIO[c.Name]
+			if !hasBaseline || !ok {
+				continue
+			}
+			network = append(network, types.NetworkMetrics{
+				Interface:       c.Name,
+				RecvBytesPerSec: rate(c.BytesRecv, last.BytesRecv, elapsed),
+				SentBytesPerSec: rate(c.BytesSent, last.BytesSent, elapsed),
+				ErrorsIn:        c.Errin,
+				ErrorsOut:       c.Errout,
+				DropsIn:         c.Dropin,
+				DropsOut:        c.Dropout,
+			})
+		}
+		p.lastNetIO = current
+	}
+
+	p.lastIOSample = now
+	return diskIO, network
+}
+
+// rate converts a counter delta into a per-second value, treating counter resets as zero
+func rate(current, last uint64, elapsed float64) float64 {
+	if current < last || elapsed <= 0 {
+		return 0
+	}
+	return round(float64(current-last)/elapsed, 2)
+}
+
+// getLoadAverage returns load averages, or nil where unsupported (e.g. Windows)
+func getLoadAverage() *types.LoadAverage {
+	avg, err := load.Avg()
+	if err != nil {
+		return nil
+	}
+	return &types.LoadAverage{
+		Load1:  avg.Load1,
+		Load5:  avg.Load5,
+		Load15: avg.Load15,
+	}
+}
@@ -0,0 +1,40 @@
+package probes
+
+import "testing"
+
+func TestMatchMountpoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		mountpoint string
+		include    []string
+		exclude    []string
+		expected   bool
+	}{
+		{"no filters", "/data", nil, nil, true},
+		{"included exact", "/", []string{"/"}, nil, true},
+		{"included glob", "/var/www", []string{"/var/*"}, nil, true},
+		{"not included", "/data", []string{"/"}, nil, false},
+		{"excluded glob", "/snap/core", nil, []string{"/snap/*"}, false},
+		{"exclude wins", "/var/log", []string{"/var/*"}, []string{"/var/log"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := matchMountpoint(tt.mountpoint, tt.include, tt.exclude)
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestRate(t *testing.T) {
+	if r := rate(3000, 1000, 2); r != 1000 {
+		t.Errorf("Expected 1000/s, got %v", r)
+	}
+
+	// Counter reset must not produce a huge or negative value
+	if r := rate(10, 1000, 2); r != 0 {
+		t.Errorf("Expected 0 on counter reset, got %v", r)
+	}
+}
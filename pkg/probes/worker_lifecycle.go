@@ -0,0 +1,310 @@
+package probes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gravito-framework/quasar-go/pkg/types"
+)
+
+// Worker lifecycle event types
+const (
+	EventWorkerStarted           = "worker.started"
+	EventWorkerExited            = "worker.exited"
+	EventWorkerRestarted         = "worker.restarted"
+	EventWorkerCrashLoop         = "worker.crash_loop"
+	EventWorkerCrashLoopResolved = "worker.crash_loop_resolved"
+)
+
+// maxPendingEvents bounds the event buffer if nobody drains it
+const maxPendingEvents = 1000
+
+// WorkerCrashLoop describes a worker that keeps exiting
+type WorkerCrashLoop struct {
+	Root    string `json:"root"`
+	Cmdline string `json:"cmdline"`
+	Slot    string `json:"slot"`   // Supervisor process name, or "#n" among workers with this cmdline
+	Exits   int    `json:"exits"`  // Exits within the detection window
+	Window  string `json:"window"` // Detection window, e.g. "5m0s"
+}
+
+// workerRecord is what we remember about a worker between scans
+type workerRecord struct {
+	identity   string // root + cmdline: shared by all copies of a program
+	process    string // Supervisor process name ("" if unknown)
+	slot       string // identity + slot label: stable across restarts of one worker, set by observe
+	root       string
+	cmdline    string
+	queues     []string
+	createTime int64 // ms since epoch, distinguishes reused PIDs
+}
+
+// workerLifecycle turns successive worker scans into lifecycle events
+type workerLifecycle struct {
+	mu          sync.Mutex
+	initialized bool
+	known       map[int32]workerRecord
+	restarts    map[string]int         // slot -> restart count
+	exits       map[string][]time.Time // slot -> recent exit times
+	lastExit    map[string]time.Time   // slot -> most recent exit
+	looping     map[string]bool        // slot -> currently in crash loop
+	expected    map[int32]time.Time    // PIDs the agent stops on purpose
+	pending     []types.Event
+
+	crashLoopExits  int
+	crashLoopWindow time.Duration
+}
+
+var lifecycle = newWorkerLifecycle(5, 5*time.Minute)
+
+func newWorkerLifecycle(exits int, window time.Duration) *workerLifecycle {
+	return &workerLifecycle{
+		known:           make(map[int32]workerRecord),
+		restarts:        make(map[string]int),
+		exits:           make(map[string][]time.Time),
+		lastExit:        make(map[string]time.Time),
+		looping:         make(map[string]bool),
+		expected:        make(map[int32]time.Time),
+		crashLoopExits:  exits,
+		crashLoopWindow: window,
+	}
+}
+
+// ConfigureCrashLoopDetection sets how many exits within a window count as a crash loop
+func ConfigureCrashLoopDetection(exits int, window time.Duration) {
+	lifecycle.mu.Lock()
+	defer lifecycle.mu.Unlock()
+	if exits > 0 {
+		lifecycle.crashLoopExits = exits
+	}
+	if window > 0 {
+		lifecycle.crashLoopWindow = window
+	}
+}
+
+// ExpectWorkerExit marks a worker the agent is about to stop on purpose, e.g.
+// when scaling down or restarting, so its exit is not counted towards
+// restarts or crash loops. Call it before signalling the worker.
+func ExpectWorkerExit(pid int32) {
+	lifecycle.expect(pid, time.Now())
+}
+
+func (l *workerLifecycle) expect(pid int32, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expected[pid] = now
+}
+
+// DrainWorkerEvents returns and clears lifecycle events collected since the last call
+func DrainWorkerEvents() []types.Event {
+	lifecycle.mu.Lock()
+	defer lifecycle.mu.Unlock()
+	events := lifecycle.pending
+	lifecycle.pending = nil
+	return events
+}
+
+// observe compares the current worker set with the previous scan.
+// The first scan only records a baseline. Exits and restarts are counted
+// per worker slot, see assignSlots.
+func (l *workerLifecycle) observe(current map[int32]workerRecord, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.assignSlots(current)
+	if !l.initialized {
+		l.known = current
+		l.initialized = true
+		return
+	}
+
+	// Exits first, so a replacement in the same scan is seen as a restart
+	for pid, prev := range l.known {
+		if cur, ok := current[pid]; ok && cur.createTime == prev.createTime {
+			continue
+		}
+
+		data := prev.eventData(pid)
+		if prev.createTime > 0 {
+			data["uptime"] = now.Sub(time.UnixMilli(prev.createTime)).Seconds()
+		}
+		if _, ok := l.expected[pid]; ok {
+			delete(l.expected, pid)
+			data["expected"] = true
+		} else {
+			l.exits[prev.slot] = append(l.exits[prev.slot], now)
+			l.lastExit[prev.slot] = now
+		}
+		l.emit(EventWorkerExited, types.SeverityInfo, fmt.Sprintf("Worker %d exited", pid), data)
+	}
+
+	for pid, cur := range current {
+		if prev, ok := l.known[pid]; ok && prev.createTime == cur.createTime {
+			continue
+		}
+
+		if exitedAt, ok := l.lastExit[cur.slot]; ok && now.Sub(exitedAt) <= l.crashLoopWindow {
+			delete(l.lastExit, cur.slot)
+			l.restarts[cur.slot]++
+			data := cur.eventData(pid)
+			data["restarts"] = l.restarts[cur.slot]
+			l.emit(EventWorkerRestarted, types.SeverityInfo, fmt.Sprintf("Worker restarted as %d", pid), data)
+		} else {
+			l.emit(EventWorkerStarted, types.SeverityInfo, fmt.Sprintf("Worker %d started", pid), cur.eventData(pid))
+		}
+	}
+
+	// Crash-loop detection: N exits within the window
+	cutoff := now.Add(-l.crashLoopWindow)
+	for pid, at := range l.expected {
+		if at.Before(cutoff) {
+			delete(l.expected, pid)
+		}
+	}
+	for slot, times := range l.exits {
+		recent := times[:0]
+		for _, t := range times {
+			if t.After(cutoff) {
+				recent = append(recent, t)
+			}
+		}
+		if len(recent) == 0 {
+			delete(l.exits, slot)
+		} else {
+			l.exits[slot] = recent
+		}
+
+		data := slotData(slot)
+		data["exits"] = len(recent)
+
+		if len(recent) >= l.crashLoopExits && !l.looping[slot] {
+			l.looping[slot] = true
+			l.emit(EventWorkerCrashLoop, types.SeverityCritical,
+				fmt.Sprintf("Worker exited %d times within %s", len(recent), l.crashLoopWindow), data)
+		} else if len(recent) < l.crashLoopExits && l.looping[slot] {
+			delete(l.looping, slot)
+			l.emit(EventWorkerCrashLoopResolved, types.SeverityInfo, "Worker crash loop resolved", data)
+		}
+	}
+	for slot := range l.looping {
+		if _, ok := l.exits[slot]; !ok {
+			delete(l.looping, slot)
+			data := slotData(slot)
+			data["exits"] = 0
+			l.emit(EventWorkerCrashLoopResolved, types.SeverityInfo, "Worker crash loop resolved", data)
+		}
+	}
+
+	l.known = current
+}
+
+// assignSlots gives every worker a slot: one worker position that is kept
+// across its restarts. Workers run by Supervisor use their process name;
+// others take over the slot of a known worker with the same PID, or the
+// lowest slot number among copies of their cmdline that no running worker
+// holds, which is the one a replaced worker left.
+func (l *workerLifecycle) assignSlots(current map[int32]workerRecord) {
+	used := make(map[string]bool)
+	var fresh []int32
+	for pid, cur := range current {
+		switch prev, ok := l.known[pid]; {
+		case cur.process != "":
+			cur.slot = cur.identity + "\x00" + cur.process
+		case ok && prev.createTime == cur.createTime && prev.slot != "":
+			cur.slot = prev.slot
+		default:
+			fresh = append(fresh, pid)
+			continue
+		}
+		current[pid] = cur
+		used[cur.slot] = true
+	}
+
+	// Oldest first, so slot numbers follow start order
+	sort.Slice(fresh, func(i, j int) bool {
+		a, b := current[fresh[i]], current[fresh[j]]
+		if a.createTime != b.createTime {
+			return a.createTime < b.createTime
+		}
+		return fresh[i] < fresh[j]
+	})
+	for _, pid := range fresh {
+		cur := current[pid]
+		for n := 1; ; n++ {
+			slot := cur.identity + "\x00#" + strconv.Itoa(n)
+			if !used[slot] {
+				cur.slot = slot
+				used[slot] = true
+				break
+			}
+		}
+		current[pid] = cur
+	}
+}
+
+// restartCount returns how often the worker in this slot was restarted
+func (l *workerLifecycle) restartCount(slot string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.restarts[slot]
+}
+
+// crashLoops lists identities currently in a crash loop
+func (l *workerLifecycle) crashLoops() []WorkerCrashLoop {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var loops []WorkerCrashLoop
+	for slot := range l.looping {
+		root, cmdline, label := splitSlot(slot)
+		loops = append(loops, WorkerCrashLoop{
+			Root:    root,
+			Cmdline: cmdline,
+			Slot:    label,
+			Exits:   len(l.exits[slot]),
+			Window:  l.crashLoopWindow.String(),
+		})
+	}
+	return loops
+}
+
+func (l *workerLifecycle) emit(eventType string, severity types.EventSeverity, message string, data map[string]interface{}) {
+	if len(l.pending) >= maxPendingEvents {
+		l.pending = l.pending[1:]
+	}
+	l.pending = append(l.pending, types.NewEvent(eventType, severity, message, data))
+}
+
+func (r workerRecord) eventData(pid int32) map[string]interface{} {
+	_, _, label := splitSlot(r.slot)
+	return map[string]interface{}{
+		"pid":     pid,
+		"root":    r.root,
+		"cmdline": r.cmdline,
+		"slot":    label,
+		"queues":  r.queues,
+	}
+}
+
+// slotData describes a slot in crash-loop events
+func slotData(slot string) map[string]interface{} {
+	root, cmdline, label := splitSlot(slot)
+	return map[string]interface{}{"root": root, "cmdline": cmdline, "slot": label}
+}
+
+func workerIdentity(root, cmdline string) string {
+	return root + "\x00" + cmdline
+}
+
+// splitSlot splits a slot key into root, cmdline and slot label
+func splitSlot(slot string) (string, string, string) {
+	parts := strings.SplitN(slot, "\x00", 3)
+	for len(parts) < 3 {
+		parts = append([]string{""}, parts...)
+	}
+	return parts[0], parts[1], parts[2]
+}
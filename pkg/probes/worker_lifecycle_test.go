@@ -0,0 +1,174 @@
+package probes
+
+import (
+	"testing"
+	"time"
+)
+
+func record(root, cmdline string, createTime int64) workerRecord {
+	return workerRecord{
+		identity:   workerIdentity(root, cmdline),
+		root:       root,
+		cmdline:    cmdline,
+		createTime: createTime,
+	}
+}
+
+func eventTypes(l *workerLifecycle) []string {
+	var result []string
+	for _, e := range l.pending {
+		result = append(result, e.Type)
+	}
+	l.pending = nil
+	return result
+}
+
+func TestWorkerLifecycleRestart(t *testing.T) {
+	l := newWorkerLifecycle(3, time.Minute)
+	now := time.Now()
+	cmd := "php artisan queue:work"
+
+	// Baseline scan emits nothing
+	l.observe(map[int32]workerRecord{100: record("/app", cmd, 1)}, now)
+	if got := eventTypes(l); len(got) != 0 {
+		t.Fatalf("Expected no events on baseline, got %v", got)
+	}
+
+	// Worker replaced within one scan -> exited + restarted
+	l.observe(map[int32]workerRecord{101: record("/app", cmd, 2)}, now.Add(10*time.Second))
+	got := eventTypes(l)
+	if len(got) != 2 || got[0] != EventWorkerExited || got[1] != EventWorkerRestarted {
+		t.Fatalf("Expected exited, restarted; got %v", got)
+	}
+	if n := l.restartCount(l.known[101].slot); n != 1 {
+		t.Errorf("Expected 1 restart, got %d", n)
+	}
+
+	// A brand-new worker is a start, not a restart
+	l.observe(map[int32]workerRecord{
+		101: record("/app", cmd, 2),
+		200: record("/app", cmd+" --queue=emails", 3),
+	}, now.Add(20*time.Second))
+	if got := eventTypes(l); len(got) != 1 || got[0] != EventWorkerStarted {
+		t.Errorf("Expected started, got %v", got)
+	}
+}
+
+func TestWorkerLifecycleCrashLoop(t *testing.T) {
+	l := newWorkerLifecycle(3, time.Minute)
+	now := time.Now()
+	cmd := "php artisan queue:work"
+
+	l.observe(map[int32]workerRecord{1: record("/app", cmd, 1)}, now)
+	for i := int32(2); i <= 4; i++ {
+		now = now.Add(5 * time.Second)
+		l.observe(map[int32]workerRecord{i: record("/app", cmd, int64(i))}, now)
+	}
+
+	got := eventTypes(l)
+	if got[len(got)-1] != EventWorkerCrashLoop {
+		t.Fatalf("Expected crash loop event last, got %v", got)
+	}
+	if loops := l.crashLoops(); len(loops) != 1 || loops[0].Exits != 3 {
+		t.Fatalf("Expected one crash loop with 3 exits, got %+v", loops)
+	}
+
+	// Stable for longer than the window -> resolved
+	l.observe(map[int32]workerRecord{4: record("/app", cmd, 4)}, now.Add(2*time.Minute))
+	if got := eventTypes(l); len(got) != 1 || got[0] != EventWorkerCrashLoopResolved {
+		t.Errorf("Expected crash loop resolved, got %v", got)
+	}
+	if loops := l.crashLoops(); len(loops) != 0 {
+		t.Errorf("Expected no crash loops, got %+v", loops)
+	}
+}
+
+func TestWorkerLifecycleExpectedExit(t *testing.T) {
+	l := newWorkerLifecycle(3, time.Minute)
+	now := time.Now()
+	cmd := "php artisan queue:work"
+
+	l.observe(map[int32]workerRecord{100: record("/app", cmd, 1), 101: record("/app", cmd, 2)}, now)
+
+	// A worker stopped by a scale-down exits without counting as a crash
+	l.expect(101, now)
+	l.observe(map[int32]workerRecord{100: record("/app", cmd, 1)}, now.Add(10*time.Second))
+	if got := eventTypes(l); len(got) != 1 || got[0] != EventWorkerExited {
+		t.Fatalf("Expected exited, got %v", got)
+	}
+
+	// A later scale-up is a start, not a restart
+	l.observe(map[int32]workerRecord{100: record("/app", cmd, 1), 102: record("/app", cmd, 3)}, now.Add(20*time.Second))
+	if got := eventTypes(l); len(got) != 1 || got[0] != EventWorkerStarted {
+		t.Errorf("Expected started, got %v", got)
+	}
+	if n := l.restartCount(l.known[102].slot); n != 0 {
+		t.Errorf("Expected no restarts, got %d", n)
+	}
+}
+
+func TestWorkerLifecyclePoolRestartIsNotACrashLoop(t *testing.T) {
+	l := newWorkerLifecycle(3, time.Minute)
+	now := time.Now()
+	cmd := "php artisan queue:work"
+
+	pool := func(firstPID int32, createTime int64) map[int32]workerRecord {
+		workers := make(map[int32]workerRecord)
+		for i := int32(0); i < 5; i++ {
+			workers[firstPID+i] = record("/app", cmd, createTime+int64(i))
+		}
+		return workers
+	}
+
+	// All 5 copies of a program replaced at once: one restart per slot
+	l.observe(pool(100, 1), now)
+	l.observe(pool(200, 10), now.Add(10*time.Second))
+	if loops := l.crashLoops(); len(loops) != 0 {
+		t.Fatalf("Expected no crash loop, got %+v", loops)
+	}
+	for pid := int32(200); pid < 205; pid++ {
+		if n := l.restartCount(l.known[pid].slot); n != 1 {
+			t.Errorf("Expected worker %d to have 1 restart, got %d", pid, n)
+		}
+	}
+	eventTypes(l)
+
+	// One copy keeps crashing: only its slot loops (the pool restart plus 3 crashes)
+	for i := int64(0); i < 3; i++ {
+		workers := pool(200, 10)
+		delete(workers, 202)
+		workers[int32(302+i*100)] = record("/app", cmd, 100+i)
+		now = now.Add(5 * time.Second)
+		l.observe(workers, now.Add(10*time.Second))
+	}
+	loops := l.crashLoops()
+	if len(loops) != 1 || loops[0].Slot != "#3" || loops[0].Exits != 4 {
+		t.Fatalf("Expected the third slot in a crash loop, got %+v", loops)
+	}
+	if n := l.restartCount(l.known[200].slot); n != 1 {
+		t.Errorf("Expected other slots to keep 1 restart, got %d", n)
+	}
+}
+
+func TestWorkerLifecycleSupervisorSlots(t *testing.T) {
+	l := newWorkerLifecycle(3, time.Minute)
+	now := time.Now()
+	cmd := "php artisan queue:work"
+
+	named := func(process string, createTime int64) workerRecord {
+		r := record("/app", cmd, createTime)
+		r.process = process
+		return r
+	}
+
+	l.observe(map[int32]workerRecord{1: named("workers:w_00", 1), 2: named("workers:w_01", 2)}, now)
+	// w_01 restarts while w_00 keeps running; the new w_01 is older in PID order
+	l.observe(map[int32]workerRecord{1: named("workers:w_00", 1), 3: named("workers:w_01", 3)}, now.Add(time.Second))
+
+	if n := l.restartCount(l.known[3].slot); n != 1 {
+		t.Errorf("Expected w_01 to have 1 restart, got %d", n)
+	}
+	if n := l.restartCount(l.known[1].slot); n != 0 {
+		t.Errorf("Expected w_00 to have no restarts, got %d", n)
+	}
+}
@@ -0,0 +1,132 @@
+package sidekiq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestRedis starts an in-memory Redis for the duration of the test
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return server, client
+}
+
+func jobPayload(jid, queue string) string {
+	return fmt.Sprintf(`{"class":"HardJob","jid":"%s","queue":"%s","retry_count":2,"enqueued_at":1700000000.5}`, jid, queue)
+}
+
+func TestCountInSet(t *testing.T) {
+	server, client := newTestRedis(t)
+	ctx := context.Background()
+	ns := Namespace("myapp")
+
+	for i, queue := range []string{"default", "critical", "default", "default*"} {
+		server.ZAdd(ns.Key(SetRetry), float64(i), jobPayload(fmt.Sprintf("jid%d", i), queue))
+	}
+
+	tests := []struct {
+		queue    string
+		expected int64
+	}{
+		{"default", 2},
+		{"critical", 1},
+		{"default*", 1}, // Glob characters are matched literally
+		{"low", 0},
+	}
+	for _, tt := range tests {
+		n, err := CountInSet(ctx, client, ns.Key(SetRetry), tt.queue)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if n != tt.expected {
+			t.Errorf("Queue %s: expected %d jobs, got %d", tt.queue, tt.expected, n)
+		}
+	}
+
+	counts, err := CountByQueue(ctx, client, ns.Key(SetRetry))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if counts["default"] != 2 || counts["critical"] != 1 || counts["default*"] != 1 || len(counts) != 3 {
+		t.Errorf("Expected counts of all queues, got %v", counts)
+	}
+}
+
+func TestBusy(t *testing.T) {
+	server, client := newTestRedis(t)
+	ctx := context.Background()
+	ns := Namespace("")
+
+	server.SAdd("processes", "host:1", "host:2")
+	server.HSet("host:1", "busy", "2")
+	server.HSet("host:1:work", "tid1", `{"queue":"default","payload":"{}"}`, "tid2", `{"queue":"critical","payload":"{}"}`)
+	// host:2 died without cleaning up: its hash expired but its work remains
+	server.HSet("host:2:work", "tid3", `{"queue":"default","payload":"{}"}`)
+
+	busy, err := Busy(ctx, client, ns)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if busy["default"] != 1 || busy["critical"] != 1 {
+		t.Errorf("Expected one busy job per queue of live processes, got %v", busy)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	server, client := newTestRedis(t)
+	ctx := context.Background()
+	ns := Namespace("myapp")
+
+	server.ZAdd(ns.Key(SetRetry), 1, jobPayload("abc", "critical"))
+	server.ZAdd(ns.Key(SetRetry), 2, jobPayload("def", "default"))
+
+	job, err := Retry(ctx, client, ns, SetRetry, "abc")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if job.Queue != "critical" {
+		t.Errorf("Expected queue critical, got %s", job.Queue)
+	}
+
+	if members, _ := server.ZMembers(ns.Key(SetRetry)); len(members) != 1 {
+		t.Errorf("Expected one job left in the retry set, got %d", len(members))
+	}
+	queued, err := server.List(ns.Queue("critical"))
+	if err != nil || len(queued) != 1 {
+		t.Fatalf("Expected the job in %s, got %v (%v)", ns.Queue("critical"), queued, err)
+	}
+	if ok, _ := server.SIsMember(ns.Key("queues"), "critical"); !ok {
+		t.Error("Expected the queue to be registered in the queues set")
+	}
+
+	if _, err := Retry(ctx, client, ns, SetRetry, "abc"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected ErrJobNotFound on a second retry, got %v", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	server, client := newTestRedis(t)
+	ctx := context.Background()
+	ns := Namespace("")
+
+	server.ZAdd(ns.Key(SetDead), 1, jobPayload("abc", "default"))
+
+	if _, err := Delete(ctx, client, ns, SetRetry, "abc"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected ErrJobNotFound in another set, got %v", err)
+	}
+	job, err := Delete(ctx, client, ns, SetDead, "abc")
+	if err != nil || job.JID != "abc" {
+		t.Fatalf("Expected job abc to be deleted, got %+v (%v)", job, err)
+	}
+	if server.Exists(ns.Key(SetDead)) {
+		t.Error("Expected the dead set to be empty")
+	}
+}
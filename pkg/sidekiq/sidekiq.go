@@ -0,0 +1,303 @@
+// Package sidekiq reads and changes Sidekiq's Redis data, so Ruby services
+// can be monitored and managed next to PHP ones.
+//
+// Key layout (under an optional redis-namespace prefix):
+//   - queue:{name}: waiting jobs (List, pushed left, popped right)
+//   - schedule, retry, dead: jobs outside queues (ZSet scored by time)
+//   - processes: running Sidekiq processes (Set), each with a {identity}
+//     hash and its running jobs in {identity}:work
+//   - stat:processed, stat:failed: lifetime counters
+package sidekiq
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Sorted sets holding jobs that are not in a queue
+const (
+	SetSchedule = "schedule"
+	SetRetry    = "retry"
+	SetDead     = "dead"
+)
+
+// scanCount is the COUNT hint for ZSCAN over the job sets
+const scanCount = 1000
+
+// ErrJobNotFound is returned when no job in a set has the given jid
+var ErrJobNotFound = errors.New("job not found")
+
+// Namespace is a redis-namespace prefix such as "myapp" (empty for none)
+type Namespace string
+
+// Key returns a key inside the namespace
+func (n Namespace) Key(key string) string {
+	if n == "" {
+		return key
+	}
+	return strings.TrimSuffix(string(n), ":") + ":" + key
+}
+
+// Queue returns the list key of a queue
+func (n Namespace) Queue(name string) string {
+	return n.Key("queue:" + name)
+}
+
+// Job is the part of a job payload the agent needs
+type Job struct {
+	JID   string `json:"jid"`
+	Class string `json:"class"`
+	Queue string `json:"queue"`
+}
+
+// CountInSet counts the jobs of a queue in a sorted set. The set is scanned
+// server-side for the queue field, so only matching jobs are transferred.
+func CountInSet(ctx context.Context, client *redis.Client, key, queue string) (int64, error) {
+	seen := make(map[string]struct{})
+	match := fieldPattern("queue", queue)
+
+	var cursor uint64
+	for {
+		// ZSCAN returns member, score pairs
+		items, next, err := client.ZScan(ctx, key, cursor, match, scanCount).Result()
+		if err != nil {
+			return 0, err
+		}
+		for i := 0; i < len(items); i += 2 {
+			if jobQueue(items[i]) == queue {
+				seen[items[i]] = struct{}{}
+			}
+		}
+		if next == 0 {
+			return int64(len(seen)), nil
+		}
+		cursor = next
+	}
+}
+
+// CountByQueue counts the jobs of every queue in a sorted set in one scan,
+// for callers that need the counts of several queues
+func CountByQueue(ctx context.Context, client *redis.Client, key string) (map[string]int64, error) {
+	seen := make(map[string]struct{})
+	counts := make(map[string]int64)
+
+	var cursor uint64
+	for {
+		items, next, err := client.ZScan(ctx, key, cursor, "", scanCount).Result()
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < len(items); i += 2 {
+			// ZSCAN may return a member more than once
+			if _, ok := seen[items[i]]; !ok {
+				seen[items[i]] = struct{}{}
+				counts[jobQueue(items[i])]++
+			}
+		}
+		if next == 0 {
+			return counts, nil
+		}
+		cursor = next
+	}
+}
+
+// Busy returns how many jobs each queue is running, from the work hashes
+// of live processes
+func Busy(ctx context.Context, client *redis.Client, ns Namespace) (map[string]int64, error) {
+	identities, err := client.SMembers(ctx, ns.Key("processes")).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := client.Pipeline()
+	alive := make([]*redis.IntCmd, len(identities))
+	work := make([]*redis.MapStringStringCmd, len(identities))
+	for i, identity := range identities {
+		alive[i] = pipe.Exists(ctx, ns.Key(identity))
+		work[i] = pipe.HGetAll(ctx, ns.Key(identity+":work"))
+	}
+	if len(identities) > 0 {
+		if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+			return nil, err
+		}
+	}
+
+	busy := make(map[string]int64)
+	for i := range identities {
+		// Processes that died without cleaning up linger in the set until pruned
+		if alive[i].Val() == 0 {
+			continue
+		}
+		for _, entry := range work[i].Val() {
+			busy[jobQueue(entry)]++
+		}
+	}
+	return busy, nil
+}
+
+// Stats returns the lifetime processed and failed counters
+func Stats(ctx context.Context, client *redis.Client, ns Namespace) (processed, failed int64, err error) {
+	values, err := client.MGet(ctx, ns.Key("stat:processed"), ns.Key("stat:failed")).Result()
+	if err != nil {
+		return 0, 0, err
+	}
+	return counter(values[0]), counter(values[1]), nil
+}
+
+// FindJob returns the raw member and decoded job with the given jid
+func FindJob(ctx context.Context, client *redis.Client, key, jid string) (string, *Job, error) {
+	match := fieldPattern("jid", jid)
+
+	var cursor uint64
+	for {
+		items, next, err := client.ZScan(ctx, key, cursor, match, scanCount).Result()
+		if err != nil {
+			return "", nil, err
+		}
+		for i := 0; i < len(items); i += 2 {
+			var job Job
+			if json.Unmarshal([]byte(items[i]), &job) == nil && job.JID == jid {
+				return items[i], &job, nil
+			}
+		}
+		if next == 0 {
+			return "", nil, ErrJobNotFound
+		}
+		cursor = next
+	}
+}
+
+// retryScript moves a job from a set to the head of its queue, as long as
+// it is still in the set (the scheduler may have moved it meanwhile).
+// KEYS[1] = set, KEYS[2] = queue list, KEYS[3] = queues set
+// ARGV[1] = member, ARGV[2] = payload to push, ARGV[3] = queue name
+var retryScript = redis.NewScript(`
+if redis.call('ZREM', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+redis.call('SADD', KEYS[3], ARGV[3])
+redis.call('LPUSH', KEYS[2], ARGV[2])
+return 1
+`)
+
+// Retry enqueues a job from the retry or dead set again, like "Retry Now"
+// in Sidekiq's web UI
+func Retry(ctx context.Context, client *redis.Client, ns Namespace, set, jid string) (*Job, error) {
+	member, job, err := FindJob(ctx, client, ns.Key(set), jid)
+	if err != nil {
+		return nil, err
+	}
+	if job.Queue == "" {
+		return job, fmt.Errorf("job %s has no queue", jid)
+	}
+
+	payload, err := PrepareRetry(member, time.Now())
+	if err != nil {
+		return job, fmt.Errorf("invalid payload: %w", err)
+	}
+
+	keys := []string{ns.Key(set), ns.Queue(job.Queue), ns.Key("queues")}
+	moved, err := retryScript.Run(ctx, client, keys, member, payload, job.Queue).Int()
+	if err != nil {
+		return job, err
+	}
+	if moved == 0 {
+		return job, ErrJobNotFound
+	}
+	return job, nil
+}
+
+// Delete removes a job from a set
+func Delete(ctx context.Context, client *redis.Client, ns Namespace, set, jid string) (*Job, error) {
+	member, job, err := FindJob(ctx, client, ns.Key(set), jid)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := client.ZRem(ctx, ns.Key(set), member).Result()
+	if err != nil {
+		return job, err
+	}
+	if removed == 0 {
+		return job, ErrJobNotFound
+	}
+	return job, nil
+}
+
+// PrepareRetry updates a payload for a manual retry like SortedEntry#retry:
+// retry_count is decremented so the attempt doesn't count, and enqueued_at
+// is set to now in the payload's own format (float seconds, or integer
+// milliseconds since Sidekiq 8). Other fields are kept.
+func PrepareRetry(payload string, now time.Time) (string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(payload), &fields); err != nil {
+		return "", err
+	}
+
+	if raw, ok := fields["retry_count"]; ok {
+		if count, err := strconv.Atoi(string(raw)); err == nil {
+			fields["retry_count"] = json.RawMessage(strconv.Itoa(count - 1))
+		}
+	}
+
+	enqueuedAt := strconv.FormatFloat(float64(now.UnixMicro())/1e6, 'f', 6, 64)
+	if raw, ok := fields["enqueued_at"]; ok {
+		if v, err := strconv.ParseInt(string(raw), 10, 64); err == nil && v > 1e12 {
+			enqueuedAt = strconv.FormatInt(now.UnixMilli(), 10)
+		}
+	}
+	fields["enqueued_at"] = json.RawMessage(enqueuedAt)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(fields); err != nil {
+		return "", err
+	}
+	return string(bytes.TrimRight(buf.Bytes(), "\n")), nil
+}
+
+// fieldPattern returns a MATCH pattern for a JSON string field. Sidekiq
+// writes compact JSON, so the field appears as "name":"value".
+func fieldPattern(field, value string) string {
+	encoded, _ := json.Marshal(value)
+	return `*"` + field + `":` + globEscape(string(encoded)) + `*`
+}
+
+// globEscape escapes Redis glob metacharacters
+func globEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`*?[]\`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// jobQueue returns the queue of a job payload or work entry ("" if invalid)
+func jobQueue(raw string) string {
+	var entry struct {
+		Queue string `json:"queue"`
+	}
+	_ = json.Unmarshal([]byte(raw), &entry)
+	return entry.Queue
+}
+
+// counter parses an MGET value of a counter (nil when never incremented)
+func counter(v interface{}) int64 {
+	s, ok := v.(string)
+	if !ok {
+		return 0
+	}
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
@@ -0,0 +1,124 @@
+package sidekiq
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNamespace(t *testing.T) {
+	tests := []struct {
+		namespace Namespace
+		expected  string
+	}{
+		{"", "queue:default"},
+		{"myapp", "myapp:queue:default"},
+		{"myapp:", "myapp:queue:default"},
+	}
+	for _, tt := range tests {
+		if got := tt.namespace.Queue("default"); got != tt.expected {
+			t.Errorf("Namespace %q: expected %s, got %s", tt.namespace, tt.expected, got)
+		}
+	}
+}
+
+func TestPrepareRetry(t *testing.T) {
+	now := time.Unix(1700000000, 250000000)
+
+	tests := []struct {
+		name       string
+		payload    string
+		retryCount string
+		enqueuedAt string
+	}{
+		{
+			name:       "retry set entry",
+			payload:    `{"class":"HardJob","args":[],"retry":true,"queue":"default","jid":"b4a577edbccf1d805744efa9","retry_count":2,"enqueued_at":1699990000.5}`,
+			retryCount: "1",
+			enqueuedAt: "1700000000.250000",
+		},
+		{
+			name:       "millisecond timestamps (Sidekiq 8)",
+			payload:    `{"class":"HardJob","args":[{"id":1}],"queue":"default","jid":"abc","retry_count":0,"enqueued_at":1699990000500}`,
+			retryCount: "-1",
+			enqueuedAt: "1700000000250",
+		},
+		{
+			name:       "never retried",
+			payload:    `{"class":"HardJob","args":["<b>"],"queue":"default","jid":"abc"}`,
+			enqueuedAt: "1700000000.250000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := PrepareRetry(tt.payload, now)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal([]byte(out), &fields); err != nil {
+				t.Fatalf("Invalid payload %s: %v", out, err)
+			}
+			if string(fields["retry_count"]) != tt.retryCount {
+				t.Errorf("Expected retry_count %q, got %q", tt.retryCount, fields["retry_count"])
+			}
+			if string(fields["enqueued_at"]) != tt.enqueuedAt {
+				t.Errorf("Expected enqueued_at %s, got %s", tt.enqueuedAt, fields["enqueued_at"])
+			}
+
+			var original map[string]json.RawMessage
+			_ = json.Unmarshal([]byte(tt.payload), &original)
+			for _, key := range []string{"class", "args", "jid", "queue"} {
+				if string(fields[key]) != string(original[key]) {
+					t.Errorf("Expected %s to be kept as %s, got %s", key, original[key], fields[key])
+				}
+			}
+		})
+	}
+}
+
+func TestFieldPattern(t *testing.T) {
+	tests := []struct {
+		field    string
+		value    string
+		expected string
+	}{
+		{"queue", "default", `*"queue":"default"*`},
+		{"queue", "low*[1]", `*"queue":"low\*\[1\]"*`},
+		{"jid", `a"b`, `*"jid":"a\\"b"*`},
+	}
+	for _, tt := range tests {
+		if got := fieldPattern(tt.field, tt.value); got != tt.expected {
+			t.Errorf("Expected %s, got %s", tt.expected, got)
+		}
+	}
+}
+
+func TestJobQueue(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected string
+	}{
+		// Sidekiq 7 work entry: payload is a JSON string
+		{`{"queue":"mailers","payload":"{\"queue\":\"mailers\",\"jid\":\"abc\"}","run_at":1700000000}`, "mailers"},
+		// Sidekiq 6 work entry: payload is an object
+		{`{"queue":"default","payload":{"queue":"default"},"run_at":1700000000}`, "default"},
+		{`not json`, ""},
+	}
+	for _, tt := range tests {
+		if got := jobQueue(tt.raw); got != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+func TestCounter(t *testing.T) {
+	if got := counter("1234"); got != 1234 {
+		t.Errorf("Expected 1234, got %d", got)
+	}
+	if got := counter(nil); got != 0 {
+		t.Errorf("Expected 0 for a missing counter, got %d", got)
+	}
+}
@@ -0,0 +1,207 @@
+package supervisor
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeSupervisor stands in for supervisord's XML-RPC interface
+type fakeSupervisor struct {
+	mu      sync.Mutex
+	running bool
+	nextPID int
+	calls   []string
+}
+
+func (f *fakeSupervisor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	var call struct {
+		Method string `xml:"methodName"`
+	}
+	_ = xml.Unmarshal(body, &call)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, call.Method)
+
+	switch call.Method {
+	case "supervisor.getAllProcessInfo":
+		state, pid := "STOPPED", 0
+		if f.running {
+			state, pid = "RUNNING", f.nextPID
+		}
+		var members strings.Builder
+		for i := 0; i < 2; i++ {
+			p := pid
+			if p > 0 {
+				p += i
+			}
+			fmt.Fprintf(&members, `<value><struct>
+<member><name>name</name><value><string>worker_%02d</string></value></member>
+<member><name>group</name><value><string>laravel-worker</string></value></member>
+<member><name>statename</name><value><string>%s</string></value></member>
+<member><name>pid</name><value><int>%d</int></value></member>
+<member><name>start</name><value><int>1000</int></value></member>
+<member><name>now</name><value><int>1060</int></value></member>
+<member><name>exitstatus</name><value><int>0</int></value></member>
+<member><name>spawnerr</name><value><string></string></value></member>
+</struct></value>`, i, state, p)
+		}
+		fmt.Fprintf(w, `<?xml version="1.0"?><methodResponse><params><param><value><array><data>%s</data></array></value></param></params></methodResponse>`, members.String())
+	case "supervisor.stopProcessGroup":
+		f.running = false
+		fmt.Fprint(w, `<?xml version="1.0"?><methodResponse><params><param><value><array><data></data></array></value></param></params></methodResponse>`)
+	case "supervisor.startProcessGroup":
+		f.running = true
+		f.nextPID += 100
+		fmt.Fprint(w, `<?xml version="1.0"?><methodResponse><params><param><value><array><data></data></array></value></param></params></methodResponse>`)
+	case "supervisor.reloadConfig":
+		// [[added, changed, removed]] with laravel-worker changed
+		fmt.Fprint(w, `<?xml version="1.0"?><methodResponse><params><param><value><array><data><value><array><data>
+<value><array><data></data></array></value>
+<value><array><data><value><string>laravel-worker</string></value></data></array></value>
+<value><array><data></data></array></value>
+</data></array></value></data></array></value></param></params></methodResponse>`)
+	case "supervisor.removeProcessGroup", "supervisor.addProcessGroup":
+		if call.Method == "supervisor.addProcessGroup" {
+			f.running = true
+			f.nextPID += 100
+		}
+		fmt.Fprint(w, `<?xml version="1.0"?><methodResponse><params><param><value><boolean>1</boolean></value></param></params></methodResponse>`)
+	default:
+		fmt.Fprint(w, `<?xml version="1.0"?><methodResponse><fault><value><struct>
+<member><name>faultCode</name><value><int>10</int></value></member>
+<member><name>faultString</name><value><string>BAD_NAME: nope</string></value></member>
+</struct></value></fault></methodResponse>`)
+	}
+}
+
+func TestClientRestartGroup(t *testing.T) {
+	fake := &fakeSupervisor{running: true, nextPID: 100}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := client.RestartGroup(context.Background(), "laravel-worker")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !result.Confirmed {
+		t.Errorf("Expected confirmed restart, got %+v", result)
+	}
+	if result.Before[0].PID != 100 || result.After[0].PID != 200 {
+		t.Errorf("Expected PIDs 100 -> 200, got %d -> %d", result.Before[0].PID, result.After[0].PID)
+	}
+	if result.After[0].Uptime != 60 {
+		t.Errorf("Expected uptime 60, got %d", result.After[0].Uptime)
+	}
+
+	expected := []string{
+		"supervisor.getAllProcessInfo",
+		"supervisor.stopProcessGroup",
+		"supervisor.startProcessGroup",
+		"supervisor.getAllProcessInfo",
+	}
+	if strings.Join(fake.calls, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected calls %v, got %v", expected, fake.calls)
+	}
+}
+
+func TestClientUpdateGroup(t *testing.T) {
+	fake := &fakeSupervisor{running: true, nextPID: 100}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := client.UpdateGroup(context.Background(), "laravel-worker")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !changed {
+		t.Error("Expected laravel-worker to be reported as changed")
+	}
+
+	expected := []string{
+		"supervisor.reloadConfig",
+		"supervisor.stopProcessGroup",
+		"supervisor.removeProcessGroup",
+		"supervisor.addProcessGroup",
+	}
+	if strings.Join(fake.calls, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected calls %v, got %v", expected, fake.calls)
+	}
+
+	// A group that did not change is left alone
+	fake.calls = nil
+	if changed, err := client.UpdateGroup(context.Background(), "scheduler"); err != nil || changed {
+		t.Errorf("Expected unchanged scheduler, got %v, %v", changed, err)
+	}
+	if len(fake.calls) != 1 {
+		t.Errorf("Expected only reloadConfig, got %v", fake.calls)
+	}
+}
+
+func TestClientFaultOverUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "supervisor.sock")
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &http.Server{Handler: &fakeSupervisor{}}
+	go func() { _ = server.Serve(ln) }()
+	defer server.Close()
+
+	client, err := NewClient("unix://" + socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.StartProcess(context.Background(), "missing:worker")
+	fault, ok := err.(*Fault)
+	if !ok {
+		t.Fatalf("Expected *Fault, got %v", err)
+	}
+	if fault.Code != FaultBadName {
+		t.Errorf("Expected BAD_NAME fault, got %d", fault.Code)
+	}
+
+	status, err := client.GetStatus(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(status.Groups) != 1 || status.Groups[0].Total != 2 || status.Groups[0].States["STOPPED"] != 2 {
+		t.Errorf("Unexpected group summary: %+v", status.Groups)
+	}
+}
+
+func TestEncodeCall(t *testing.T) {
+	data, err := encodeCall("supervisor.startProcess", "a&b", true, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := string(data)
+	for _, want := range []string{"<string>a&amp;b</string>", "<boolean>1</boolean>", "<int>3</int>"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %s in %s", want, got)
+		}
+	}
+}
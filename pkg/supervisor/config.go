@@ -0,0 +1,143 @@
+package supervisor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SetNumprocs rewrites numprocs of a [program:x] section in the first config
+// file matching one of the glob patterns. It returns the file and the previous
+// value. Run ReloadConfig and UpdateGroup afterwards to apply it.
+func SetNumprocs(patterns []string, program string, numprocs int) (string, int, error) {
+	for _, pattern := range patterns {
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid supervisor config pattern %q: %w", pattern, err)
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				continue
+			}
+			updated, previous, found, err := setNumprocs(string(data), program, numprocs)
+			if !found {
+				continue
+			}
+			if err != nil {
+				return file, previous, err
+			}
+			if previous == numprocs {
+				return file, previous, nil
+			}
+			return file, previous, writeFileAtomic(file, []byte(updated))
+		}
+	}
+	return "", 0, fmt.Errorf("no supervisor config defines [program:%s] (searched %s)", program, strings.Join(patterns, ", "))
+}
+
+// setNumprocs sets numprocs in the program's section, adding the key if it is
+// missing. found reports whether the section exists.
+func setNumprocs(content, program string, numprocs int) (updated string, previous int, found bool, err error) {
+	lines := strings.SplitAfter(content, "\n")
+	header := "[program:" + program + "]"
+
+	start, end := -1, len(lines)
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if start < 0 {
+			if trimmed == header {
+				start = i
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "[") {
+			end = i
+			break
+		}
+	}
+	if start < 0 {
+		return content, 0, false, nil
+	}
+
+	previous = 1
+	numprocsLine, lastKey := -1, start
+	processName := ""
+	for i := start + 1; i < end; i++ {
+		key, value, ok := iniKeyValue(lines[i])
+		if !ok {
+			continue
+		}
+		lastKey = i
+		switch key {
+		case "numprocs":
+			numprocsLine = i
+			if n, err := strconv.Atoi(value); err == nil {
+				previous = n
+			}
+		case "process_name":
+			processName = value
+		}
+	}
+
+	// Supervisor refuses several processes that would share one name
+	if numprocs > 1 && !strings.Contains(processName, "%(process_num)") {
+		return content, previous, true, fmt.Errorf("[program:%s] needs process_name with %%(process_num)s to run more than one process", program)
+	}
+
+	line := fmt.Sprintf("numprocs=%d\n", numprocs)
+	if numprocsLine >= 0 {
+		lines[numprocsLine] = line
+	} else {
+		if !strings.HasSuffix(lines[lastKey], "\n") {
+			lines[lastKey] += "\n"
+		}
+		lines = append(lines[:lastKey+1], append([]string{line}, lines[lastKey+1:]...)...)
+	}
+	return strings.Join(lines, ""), previous, true, nil
+}
+
+// iniKeyValue parses a "key = value" line, ignoring comments
+func iniKeyValue(line string) (string, string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || trimmed[0] == ';' || trimmed[0] == '#' {
+		return "", "", false
+	}
+	key, value, ok := strings.Cut(trimmed, "=")
+	if !ok {
+		return "", "", false
+	}
+	// Inline comments need a space before the semicolon
+	if i := strings.Index(value, " ;"); i >= 0 {
+		value = value[:i]
+	}
+	return strings.TrimSpace(key), strings.TrimSpace(value), true
+}
+
+// writeFileAtomic replaces a file via rename, keeping its permissions
+func writeFileAtomic(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
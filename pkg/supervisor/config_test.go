@@ -0,0 +1,84 @@
+package supervisor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const workerConfig = `[program:laravel-worker]
+process_name=%(program_name)s_%(process_num)02d
+command=php /var/www/app/artisan queue:work redis --queue=default
+numprocs = 2 ; two to start with
+autostart=true
+
+[program:scheduler]
+command=php /var/www/app/artisan schedule:work
+`
+
+func TestSetNumprocsContent(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		program      string
+		numprocs     int
+		wantFound    bool
+		wantErr      bool
+		wantPrevious int
+		wantLine     string
+	}{
+		{"replace", workerConfig, "laravel-worker", 5, true, false, 2, "numprocs=5\nautostart=true"},
+		{"add missing key", workerConfig, "scheduler", 1, true, false, 1, "schedule:work\nnumprocs=1\n"},
+		{"single name cannot scale", workerConfig, "scheduler", 3, true, true, 1, ""},
+		{"no trailing newline", "[program:w]\nprocess_name=w_%(process_num)s", "w", 2, true, false, 1, "%(process_num)s\nnumprocs=2\n"},
+		{"missing section", workerConfig, "horizon", 2, false, false, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, previous, found, err := setNumprocs(tt.content, tt.program, tt.numprocs)
+			if found != tt.wantFound || (err != nil) != tt.wantErr {
+				t.Fatalf("Expected found=%v err=%v, got found=%v err=%v", tt.wantFound, tt.wantErr, found, err)
+			}
+			if previous != tt.wantPrevious {
+				t.Errorf("Expected previous %d, got %d", tt.wantPrevious, previous)
+			}
+			if tt.wantLine != "" && !strings.Contains(updated, tt.wantLine) {
+				t.Errorf("Expected %q in:\n%s", tt.wantLine, updated)
+			}
+		})
+	}
+}
+
+func TestSetNumprocsFile(t *testing.T) {
+	dir := t.TempDir()
+	other := filepath.Join(dir, "a-other.conf")
+	worker := filepath.Join(dir, "b-worker.conf")
+	if err := os.WriteFile(other, []byte("[program:other]\ncommand=sleep 1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(worker, []byte(workerConfig), 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	file, previous, err := SetNumprocs([]string{filepath.Join(dir, "*.conf")}, "laravel-worker", 4)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if file != worker || previous != 2 {
+		t.Errorf("Expected %s with previous 2, got %s with %d", worker, file, previous)
+	}
+
+	data, _ := os.ReadFile(worker)
+	if !strings.Contains(string(data), "numprocs=4\n") {
+		t.Errorf("Expected numprocs=4 in:\n%s", data)
+	}
+	if info, _ := os.Stat(worker); info.Mode().Perm() != 0o640 {
+		t.Errorf("Expected mode 0640 to be kept, got %v", info.Mode().Perm())
+	}
+
+	if _, _, err := SetNumprocs([]string{filepath.Join(dir, "*.conf")}, "missing", 2); err == nil {
+		t.Error("Expected error for unknown program")
+	}
+}
@@ -0,0 +1,144 @@
+package supervisor
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Fault is an XML-RPC fault returned by Supervisor (e.g. BAD_NAME, NOT_RUNNING)
+type Fault struct {
+	Code   int
+	String string
+}
+
+func (f *Fault) Error() string {
+	return fmt.Sprintf("supervisor fault %d: %s", f.Code, f.String)
+}
+
+// Supervisor fault codes we react to
+const (
+	FaultBadName        = 10
+	FaultAlreadyStarted = 60
+	FaultNotRunning     = 70
+)
+
+// encodeCall builds an XML-RPC methodCall document.
+// Supported param types: string, int, bool.
+func encodeCall(method string, params ...interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString("<methodCall><methodName>")
+	if err := xml.EscapeText(&buf, []byte(method)); err != nil {
+		return nil, err
+	}
+	buf.WriteString("</methodName><params>")
+
+	for _, p := range params {
+		buf.WriteString("<param><value>")
+		switch v := p.(type) {
+		case string:
+			buf.WriteString("<string>")
+			if err := xml.EscapeText(&buf, []byte(v)); err != nil {
+				return nil, err
+			}
+			buf.WriteString("</string>")
+		case int:
+			buf.WriteString("<int>" + strconv.Itoa(v) + "</int>")
+		case bool:
+			b := "0"
+			if v {
+				b = "1"
+			}
+			buf.WriteString("<boolean>" + b + "</boolean>")
+		default:
+			return nil, fmt.Errorf("unsupported XML-RPC param type %T", p)
+		}
+		buf.WriteString("</value></param>")
+	}
+
+	buf.WriteString("</params></methodCall>")
+	return buf.Bytes(), nil
+}
+
+// xmlValue mirrors an XML-RPC <value> element
+type xmlValue struct {
+	String  *string   `xml:"string"`
+	Int     *string   `xml:"int"`
+	I4      *string   `xml:"i4"`
+	Boolean *string   `xml:"boolean"`
+	Double  *string   `xml:"double"`
+	Array   *xmlArray `xml:"array"`
+	Struct  *struct {
+		Members []struct {
+			Name  string   `xml:"name"`
+			Value xmlValue `xml:"value"`
+		} `xml:"member"`
+	} `xml:"struct"`
+	Text string `xml:",chardata"` // Untyped values are strings
+}
+
+type xmlArray struct {
+	Values []xmlValue `xml:"data>value"`
+}
+
+// xmlResponse mirrors an XML-RPC <methodResponse> document
+type xmlResponse struct {
+	Params []xmlValue `xml:"params>param>value"`
+	Fault  *xmlValue  `xml:"fault>value"`
+}
+
+// decodeResponse parses a methodResponse into Go values:
+// string, int, bool, float64, []interface{} and map[string]interface{}
+func decodeResponse(data []byte) (interface{}, error) {
+	var resp xmlResponse
+	if err := xml.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("invalid XML-RPC response: %w", err)
+	}
+
+	if resp.Fault != nil {
+		fault, _ := resp.Fault.decode().(map[string]interface{})
+		code, _ := fault["faultCode"].(int)
+		msg, _ := fault["faultString"].(string)
+		return nil, &Fault{Code: code, String: msg}
+	}
+
+	if len(resp.Params) == 0 {
+		return nil, nil
+	}
+	return resp.Params[0].decode(), nil
+}
+
+func (v xmlValue) decode() interface{} {
+	switch {
+	case v.String != nil:
+		return *v.String
+	case v.Int != nil:
+		n, _ := strconv.Atoi(strings.TrimSpace(*v.Int))
+		return n
+	case v.I4 != nil:
+		n, _ := strconv.Atoi(strings.TrimSpace(*v.I4))
+		return n
+	case v.Boolean != nil:
+		return strings.TrimSpace(*v.Boolean) == "1"
+	case v.Double != nil:
+		f, _ := strconv.ParseFloat(strings.TrimSpace(*v.Double), 64)
+		return f
+	case v.Array != nil:
+		values := make([]interface{}, 0, len(v.Array.Values))
+		for _, item := range v.Array.Values {
+			values = append(values, item.decode())
+		}
+		return values
+	case v.Struct != nil:
+		m := make(map[string]interface{}, len(v.Struct.Members))
+		for _, member := range v.Struct.Members {
+			m[member.Name] = member.Value.decode()
+		}
+		return m
+	default:
+		return v.Text
+	}
+}
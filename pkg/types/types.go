@@ -24,6 +24,7 @@ const (
 	DriverRedis    QueueDriver = "redis"
 	DriverSQS      QueueDriver = "sqs"
 	DriverRabbitMQ QueueDriver = "rabbitmq"
+	DriverSidekiq  QueueDriver = "sidekiq"
 )
 
 // QueueSize contains queue depth metrics
@@ -32,6 +33,10 @@ type QueueSize struct {
 	Active  int64 `json:"active"`
 	Failed  int64 `json:"failed"`
 	Delayed int64 `json:"delayed"`
+
+	// Breakdown of Failed for drivers that keep retrying jobs (Sidekiq)
+	Retry int64 `json:"retry,omitempty"` // Failed, waiting for an automatic retry
+	Dead  int64 `json:"dead,omitempty"`  // Out of retries
 }
 
 // QueueThroughput contains throughput metrics (jobs/min)
@@ -40,19 +45,59 @@ type QueueThroughput struct {
 	Out float64 `json:"out"`
 }
 
+// QueueStats are job counters kept by the queue system itself
+type QueueStats struct {
+	Processed       int64   `json:"processed"` // Lifetime total
+	Failed          int64   `json:"failed"`    // Lifetime total
+	ProcessedPerMin float64 `json:"processedPerMin"`
+	FailedPerMin    float64 `json:"failedPerMin"`
+}
+
 // QueueSnapshot represents a point-in-time queue state
 type QueueSnapshot struct {
 	Name       string           `json:"name"`
+	Project    string           `json:"project,omitempty"` // Laravel project the queue belongs to (multi-project hosts)
 	Driver     QueueDriver      `json:"driver"`
 	Size       QueueSize        `json:"size"`
 	Throughput *QueueThroughput `json:"throughput,omitempty"`
+	Forecast   *QueueForecast   `json:"forecast,omitempty"`
+	Paused     bool             `json:"paused,omitempty"` // Workers skip this queue (Laravel pause flag or paused Horizon supervisors)
+	Stats      *QueueStats      `json:"stats,omitempty"`  // Counters of the whole queue system, not just this queue (Sidekiq)
+}
+
+// QueueForecast estimates where the backlog is heading, smoothed over a window
+type QueueForecast struct {
+	GrowthRate   float64  `json:"growthRate"`             // Waiting jobs/min (negative = draining)
+	DrainSeconds *float64 `json:"drainSeconds,omitempty"` // Estimated time until empty (absent while not draining)
+	Growing      bool     `json:"growing"`                // Backlog is non-empty and increasing
+}
+
+// CPUBreakdown contains the share of CPU time spent in each state (0-100)
+type CPUBreakdown struct {
+	User    float64 `json:"user"`
+	Nice    float64 `json:"nice"`
+	System  float64 `json:"system"`
+	Idle    float64 `json:"idle"`
+	Iowait  float64 `json:"iowait"`
+	Irq     float64 `json:"irq"`
+	Softirq float64 `json:"softirq"`
+	Steal   float64 `json:"steal"` // Time stolen by the hypervisor (VMs only)
+}
+
+// CPUCoreMetrics contains usage data for a single logical core
+type CPUCoreMetrics struct {
+	Core      string       `json:"core"`  // e.g. "cpu0"
+	Usage     float64      `json:"usage"` // Busy % (0-100)
+	Breakdown CPUBreakdown `json:"breakdown"`
 }
 
 // CPUMetrics contains CPU usage data
 type CPUMetrics struct {
-	System  float64 `json:"system"`  // System-wide CPU % (0-100)
-	Process float64 `json:"process"` // This process CPU % (0-100)
-	Cores   int     `json:"cores"`   // Number of CPU cores
+	System    float64          `json:"system"`              // System-wide CPU % (0-100)
+	Process   float64          `json:"process"`             // This process CPU % (0-100)
+	Cores     int              `json:"cores"`               // Number of CPU cores
+	Breakdown *CPUBreakdown    `json:"breakdown,omitempty"` // Per-state system-wide breakdown
+	PerCore   []CPUCoreMetrics `json:"perCore,omitempty"`   // Optional per-core figures
 }
 
 // SystemMemory contains system-wide memory metrics
@@ -69,10 +114,129 @@ type ProcessMemory struct {
 	HeapUsed  uint64 `json:"heapUsed"`  // Not applicable for Go, use RSS
 }
 
+// SwapMemory contains system-wide swap metrics
+type SwapMemory struct {
+	Total       uint64  `json:"total"`       // Total bytes
+	Used        uint64  `json:"used"`        // Used bytes
+	Free        uint64  `json:"free"`        // Free bytes
+	UsedPercent float64 `json:"usedPercent"` // Used % (0-100)
+}
+
 // MemoryMetrics contains both system and process memory
 type MemoryMetrics struct {
 	System  SystemMemory  `json:"system"`
 	Process ProcessMemory `json:"process"`
+	Swap    *SwapMemory   `json:"swap,omitempty"`
+}
+
+// DiskMetrics contains usage data for a single mountpoint
+type DiskMetrics struct {
+	Mountpoint        string  `json:"mountpoint"`
+	Device            string  `json:"device"`
+	Fstype            string  `json:"fstype"`
+	Total             uint64  `json:"total"`             // Total bytes
+	Free              uint64  `json:"free"`              // Free bytes
+	Used              uint64  `json:"used"`              // Used bytes
+	UsedPercent       float64 `json:"usedPercent"`       // Used % (0-100)
+	InodesTotal       uint64  `json:"inodesTotal"`       // Total inodes
+	InodesUsed        uint64  `json:"inodesUsed"`        // Used inodes
+	InodesUsedPercent float64 `json:"inodesUsedPercent"` // Used inodes % (0-100)
+}
+
+// DiskIOMetrics contains IO rates for a single block device
+type DiskIOMetrics struct {
+	Device           string  `json:"device"`
+	ReadBytesPerSec  float64 `json:"readBytesPerSec"`
+	WriteBytesPerSec float64 `json:"writeBytesPerSec"`
+	ReadOpsPerSec    float64 `json:"readOpsPerSec"`
+	WriteOpsPerSec   float64 `json:"writeOpsPerSec"`
+}
+
+// NetworkMetrics contains throughput and error data for a single interface
+type NetworkMetrics struct {
+	Interface       string  `json:"interface"`
+	RecvBytesPerSec float64 `json:"recvBytesPerSec"`
+	SentBytesPerSec float64 `json:"sentBytesPerSec"`
+	ErrorsIn        uint64  `json:"errorsIn"`  // Cumulative receive errors
+	ErrorsOut       uint64  `json:"errorsOut"` // Cumulative send errors
+	DropsIn         uint64  `json:"dropsIn"`   // Cumulative dropped inbound packets
+	DropsOut        uint64  `json:"dropsOut"`  // Cumulative dropped outbound packets
+}
+
+// LoadAverage contains the 1, 5 and 15 minute load averages
+type LoadAverage struct {
+	Load1  float64 `json:"load1"`
+	Load5  float64 `json:"load5"`
+	Load15 float64 `json:"load15"`
+}
+
+// PressureLine contains one line of a Linux PSI file ("some" or "full")
+type PressureLine struct {
+	Avg10  float64 `json:"avg10"`  // % of time stalled over the last 10s
+	Avg60  float64 `json:"avg60"`  // % of time stalled over the last 60s
+	Avg300 float64 `json:"avg300"` // % of time stalled over the last 300s
+	Total  uint64  `json:"total"`  // Cumulative stall time in microseconds
+}
+
+// PressureStats contains the some/full stall figures for one resource
+type PressureStats struct {
+	Some *PressureLine `json:"some,omitempty"`
+	Full *PressureLine `json:"full,omitempty"`
+}
+
+// PressureMetrics contains PSI figures for CPU, memory and IO
+type PressureMetrics struct {
+	CPU    *PressureStats `json:"cpu,omitempty"`
+	Memory *PressureStats `json:"memory,omitempty"`
+	IO     *PressureStats `json:"io,omitempty"`
+}
+
+// Value looks up a single PSI figure, e.g. ("memory", "full", "avg60").
+// It returns false when the resource, line or window is not available.
+func (m *PressureMetrics) Value(resource, kind, window string) (float64, bool) {
+	if m == nil {
+		return 0, false
+	}
+
+	var stats *PressureStats
+	switch resource {
+	case "cpu":
+		stats = m.CPU
+	case "memory":
+		stats = m.Memory
+	case "io":
+		stats = m.IO
+	}
+	if stats == nil {
+		return 0, false
+	}
+
+	var line *PressureLine
+	switch kind {
+	case "some":
+		line = stats.Some
+	case "full":
+		line = stats.Full
+	}
+	if line == nil {
+		return 0, false
+	}
+
+	switch window {
+	case "avg10":
+		return line.Avg10, true
+	case "avg60":
+		return line.Avg60, true
+	case "avg300":
+		return line.Avg300, true
+	}
+	return 0, false
+}
+
+// PressureInfo contains host-wide and cgroup-level PSI figures
+type PressureInfo struct {
+	System *PressureMetrics `json:"system,omitempty"` // From /proc/pressure
+	Cgroup *PressureMetrics `json:"cgroup,omitempty"` // From the agent's (or configured) cgroup
 }
 
 // RuntimeInfo contains runtime metadata
@@ -94,12 +258,50 @@ type HeartbeatPayload struct {
 	Platform  string                 `json:"platform"`
 	CPU       CPUMetrics             `json:"cpu"`
 	Memory    MemoryMetrics          `json:"memory"`
+	Disks     []DiskMetrics          `json:"disks,omitempty"`
+	DiskIO    []DiskIOMetrics        `json:"diskIo,omitempty"`
+	Network   []NetworkMetrics       `json:"network,omitempty"`
+	Load      *LoadAverage           `json:"load,omitempty"`
+	Pressure  *PressureInfo          `json:"pressure,omitempty"`
 	Queues    []QueueSnapshot        `json:"queues,omitempty"`
 	Runtime   RuntimeInfo            `json:"runtime"`
 	Meta      map[string]interface{} `json:"meta,omitempty"` // Extra metadata like Laravel root, worker count
 	Timestamp int64                  `json:"timestamp"`
 }
 
+// ============================================
+// Agent Events
+// ============================================
+
+// EventSeverity represents how urgent an agent event is
+type EventSeverity string
+
+const (
+	SeverityInfo     EventSeverity = "info"
+	SeverityWarning  EventSeverity = "warning"
+	SeverityCritical EventSeverity = "critical"
+)
+
+// Event is something the agent observed locally (worker lifecycle, alerts, ...)
+type Event struct {
+	Type      string                 `json:"type"` // e.g. "worker.exited", "worker.crash_loop"
+	Severity  EventSeverity          `json:"severity"`
+	Message   string                 `json:"message"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	Timestamp int64                  `json:"timestamp"`
+}
+
+// NewEvent creates an event stamped with the current time
+func NewEvent(eventType string, severity EventSeverity, message string, data map[string]interface{}) Event {
+	return Event{
+		Type:      eventType,
+		Severity:  severity,
+		Message:   message,
+		Data:      data,
+		Timestamp: time.Now().UnixMilli(),
+	}
+}
+
 // ============================================
 // Remote Control Types (Phase 3)
 // ============================================
@@ -108,13 +310,21 @@ type HeartbeatPayload struct {
 type CommandType string
 
 const (
-	CmdRetryJob      CommandType = "RETRY_JOB"
-	CmdDeleteJob     CommandType = "DELETE_JOB"
-	CmdLaravelAction CommandType = "LARAVEL_ACTION"
+	CmdRetryJob         CommandType = "RETRY_JOB"
+	CmdDeleteJob        CommandType = "DELETE_JOB"
+	CmdLaravelAction    CommandType = "LARAVEL_ACTION"
+	CmdSupervisorAction CommandType = "SUPERVISOR_ACTION"
+	CmdScaleWorkers     CommandType = "SCALE_WORKERS"
+	CmdPeekJobs         CommandType = "PEEK_JOBS"
+	CmdBulkAction       CommandType = "BULK_ACTION"
+	CmdPauseQueue       CommandType = "PAUSE_QUEUE"
+	CmdResumeQueue      CommandType = "RESUME_QUEUE"
+	CmdWorkerAction     CommandType = "WORKER_ACTION"
+	CmdRunArtisan       CommandType = "RUN_ARTISAN"
 )
 
 // AllowedCommands is the security allowlist
-var AllowedCommands = []CommandType{CmdRetryJob, CmdDeleteJob, CmdLaravelAction}
+var AllowedCommands = []CommandType{CmdRetryJob, CmdDeleteJob, CmdLaravelAction, CmdSupervisorAction, CmdScaleWorkers, CmdPeekJobs, CmdBulkAction, CmdPauseQueue, CmdResumeQueue, CmdWorkerAction, CmdRunArtisan}
 
 // IsAllowed checks if a command type is in the allowlist
 func (c CommandType) IsAllowed() bool {
@@ -128,11 +338,32 @@ func (c CommandType) IsAllowed() bool {
 
 // CommandPayload contains command-specific data
 type CommandPayload struct {
-	Queue  string      `json:"queue,omitempty"`
-	JobID  string      `json:"jobId,omitempty"`
-	JobKey string      `json:"jobKey,omitempty"`
-	Driver QueueDriver `json:"driver,omitempty"`
-	Action string      `json:"action,omitempty"` // For LARAVEL_ACTION
+	Queue      string      `json:"queue,omitempty"`
+	JobID      string      `json:"jobId,omitempty"`
+	JobKey     string      `json:"jobKey,omitempty"`
+	Driver     QueueDriver `json:"driver,omitempty"`
+	Action     string      `json:"action,omitempty"`     // For LARAVEL_ACTION, SUPERVISOR_ACTION and WORKER_ACTION
+	Program    string      `json:"program,omitempty"`    // Supervisor group or "group:name" process, or Horizon supervisor for PAUSE_QUEUE
+	Count      *int        `json:"count,omitempty"`      // Target worker count for SCALE_WORKERS
+	Connection string      `json:"connection,omitempty"` // Laravel queue connection (default: redis)
+	State      string      `json:"state,omitempty"`      // Job state for PEEK_JOBS: waiting, delayed, reserved, failed (Sidekiq RETRY_JOB/DELETE_JOB: retry, dead)
+	Offset     int         `json:"offset,omitempty"`     // Paging offset for PEEK_JOBS
+	Limit      int         `json:"limit,omitempty"`      // Page size for PEEK_JOBS
+	Target     string      `json:"target,omitempty"`     // Destination queue for BULK_ACTION move
+	DryRun     bool        `json:"dryRun,omitempty"`     // Report matches without changing anything
+	Filter     *JobFilter  `json:"filter,omitempty"`     // Job selection for BULK_ACTION
+	PIDs       []int32     `json:"pids,omitempty"`       // Worker PIDs for WORKER_ACTION (or all workers of Queue)
+	Command    string      `json:"command,omitempty"`    // Artisan command for RUN_ARTISAN, e.g. "cache:clear"
+	Args       []string    `json:"args,omitempty"`       // Artisan arguments and options for RUN_ARTISAN
+	Project    string      `json:"project,omitempty"`    // Target Laravel project on multi-project hosts (name or root)
+}
+
+// JobFilter selects jobs for bulk actions. All set fields must match.
+type JobFilter struct {
+	Class     string   `json:"class,omitempty"`     // Display name, exact or with a trailing * (e.g. App\Jobs\*)
+	OlderThan int64    `json:"olderThan,omitempty"` // Minimum age in seconds, by pushedAt
+	Contains  string   `json:"contains,omitempty"`  // Substring of the raw payload
+	IDs       []string `json:"ids,omitempty"`       // Exact job IDs (UUID or queue-assigned ID)
 }
 
 // QuasarCommand represents a command from Zenith
@@ -159,9 +390,31 @@ type CommandResult struct {
 	CommandID string        `json:"commandId"`
 	Status    CommandStatus `json:"status"`
 	Message   string        `json:"message,omitempty"`
+	Data      interface{}   `json:"data,omitempty"` // Optional structured result
 	Timestamp int64         `json:"timestamp"`
 }
 
+// Command output kinds
+const (
+	OutputText     = "output"   // A chunk of process output
+	OutputProgress = "progress" // Items done so far
+	OutputResult   = "result"   // Terminal entry carrying the CommandResult
+)
+
+// CommandOutput is one entry of a running command's output stream.
+// Seq increases per node; the last entry of a node has Kind "result".
+type CommandOutput struct {
+	CommandID string         `json:"commandId"`
+	NodeID    string         `json:"nodeId"`
+	Seq       int64          `json:"seq"`
+	Kind      string         `json:"kind"`
+	Text      string         `json:"text,omitempty"`
+	Done      int64          `json:"done,omitempty"`
+	Total     int64          `json:"total,omitempty"` // 0 when unknown
+	Result    *CommandResult `json:"result,omitempty"`
+	Timestamp int64          `json:"timestamp"`
+}
+
 // NewSuccessResult creates a success result
 func NewSuccessResult(commandID, message string) CommandResult {
 	return CommandResult{
@@ -172,6 +425,13 @@ func NewSuccessResult(commandID, message string) CommandResult {
 	}
 }
 
+// NewSuccessResultWithData creates a success result carrying structured data
+func NewSuccessResultWithData(commandID, message string, data interface{}) CommandResult {
+	result := NewSuccessResult(commandID, message)
+	result.Data = data
+	return result
+}
+
 // NewFailedResult creates a failed result
 func NewFailedResult(commandID, message string) CommandResult {
 	return CommandResult{
@@ -181,3 +441,10 @@ func NewFailedResult(commandID, message string) CommandResult {
 		Timestamp: time.Now().UnixMilli(),
 	}
 }
+
+// NewFailedResultWithData creates a failed result carrying structured data
+func NewFailedResultWithData(commandID, message string, data interface{}) CommandResult {
+	result := NewFailedResult(commandID, message)
+	result.Data = data
+	return result
+}
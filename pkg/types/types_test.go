@@ -13,6 +13,14 @@ func TestCommandTypeIsAllowed(t *testing.T) {
 	}{
 		{"RETRY_JOB allowed", CmdRetryJob, true},
 		{"DELETE_JOB allowed", CmdDeleteJob, true},
+		{"SUPERVISOR_ACTION allowed", CmdSupervisorAction, true},
+		{"SCALE_WORKERS allowed", CmdScaleWorkers, true},
+		{"PEEK_JOBS allowed", CmdPeekJobs, true},
+		{"BULK_ACTION allowed", CmdBulkAction, true},
+		{"PAUSE_QUEUE allowed", CmdPauseQueue, true},
+		{"RESUME_QUEUE allowed", CmdResumeQueue, true},
+		{"WORKER_ACTION allowed", CmdWorkerAction, true},
+		{"RUN_ARTISAN allowed", CmdRunArtisan, true},
 		{"unknown command not allowed", CommandType("UNKNOWN"), false},
 		{"empty command not allowed", CommandType(""), false},
 	}
@@ -0,0 +1,157 @@
+// Package workers provides a built-in process manager for local queue workers,
+// used when workers are not run under Supervisor.
+package workers
+
+import (
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Spec describes how to start a queue worker
+type Spec struct {
+	Root       string // Laravel project root (working directory)
+	PHP        string // PHP binary (default: php)
+	Connection string // Queue connection (default: redis)
+	Queue      string // Queue name
+}
+
+// Worker is a queue worker started by the Manager
+type Worker struct {
+	PID       int       `json:"pid"`
+	Spec      Spec      `json:"-"`
+	Queue     string    `json:"queue"`
+	StartedAt time.Time `json:"startedAt"`
+
+	cmd  *exec.Cmd
+	done chan struct{}
+}
+
+// Manager starts and stops queue:work processes owned by the agent
+type Manager struct {
+	logger      *slog.Logger
+	gracePeriod time.Duration
+	mu          sync.Mutex
+	workers     map[int]*Worker
+}
+
+// NewManager creates a worker manager. gracePeriod is how long a worker may
+// take to finish its current job after SIGTERM before it is killed.
+func NewManager(logger *slog.Logger, gracePeriod time.Duration) *Manager {
+	if gracePeriod <= 0 {
+		gracePeriod = 30 * time.Second
+	}
+	return &Manager{
+		logger:      logger,
+		gracePeriod: gracePeriod,
+		workers:     make(map[int]*Worker),
+	}
+}
+
+// Start launches a new `php artisan queue:work` process
+func (m *Manager) Start(spec Spec) (*Worker, error) {
+	if spec.Root == "" || spec.Queue == "" {
+		return nil, fmt.Errorf("worker root and queue are required")
+	}
+	if spec.PHP == "" {
+		spec.PHP = "php"
+	}
+	if spec.Connection == "" {
+		spec.Connection = "redis"
+	}
+
+	cmd := exec.Command(spec.PHP, "artisan", "queue:work", spec.Connection, "--queue="+spec.Queue)
+	cmd.Dir = spec.Root
+	// Own process group so signals to the agent's terminal don't reach workers
+	cmd.SysProcAttr = newSysProcAttr()
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start worker: %w", err)
+	}
+
+	w := &Worker{
+		PID:       cmd.Process.Pid,
+		Spec:      spec,
+		Queue:     spec.Queue,
+		StartedAt: time.Now(),
+		cmd:       cmd,
+		done:      make(chan struct{}),
+	}
+
+	m.mu.Lock()
+	m.workers[w.PID] = w
+	m.mu.Unlock()
+
+	// Reap the process and forget it once it exits
+	go func() {
+		err := cmd.Wait()
+		m.mu.Lock()
+		delete(m.workers, w.PID)
+		m.mu.Unlock()
+		close(w.done)
+		m.logger.Info("Managed worker exited", "pid", w.PID, "queue", w.Queue, "error", err)
+	}()
+
+	m.logger.Info("Started managed worker", "pid", w.PID, "queue", spec.Queue, "root", spec.Root)
+	return w, nil
+}
+
+// Stop sends SIGTERM to a managed worker and waits for it to exit,
+// escalating to SIGKILL after the grace period
+func (m *Manager) Stop(pid int) error {
+	m.mu.Lock()
+	w, ok := m.workers[pid]
+	m.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("PID %d is not a managed worker", pid)
+	}
+
+	if err := w.cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		return fmt.Errorf("failed to signal PID %d: %w", pid, err)
+	}
+
+	select {
+	case <-w.done:
+		return nil
+	case <-time.After(m.gracePeriod):
+		m.logger.Warn("Managed worker did not exit in time, killing", "pid", pid)
+		_ = w.cmd.Process.Kill()
+		<-w.done
+		return nil
+	}
+}
+
+// List returns managed workers of a project root serving a queue, oldest first.
+// Empty root or queue match all.
+func (m *Manager) List(root, queue string) []*Worker {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []*Worker
+	for _, w := range m.workers {
+		if (root == "" || w.Spec.Root == root) && (queue == "" || w.Queue == queue) {
+			result = append(result, w)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].StartedAt.Before(result[j].StartedAt) })
+	return result
+}
+
+// StopAll stops every managed worker (used on agent shutdown)
+func (m *Manager) StopAll() {
+	var wg sync.WaitGroup
+	for _, w := range m.List("", "") {
+		wg.Add(1)
+		go func(pid int) {
+			defer wg.Done()
+			if err := m.Stop(pid); err != nil {
+				m.logger.Warn("Failed to stop managed worker", "pid", pid, "error", err)
+			}
+		}(w.PID)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,96 @@
+package workers
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// fakePHP writes a script that stands in for `php artisan queue:work`
+func fakePHP(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "php")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestManagerStartListStop(t *testing.T) {
+	php := fakePHP(t, `trap 'exit 0' TERM; while :; do sleep 0.05; done`)
+	root := t.TempDir()
+	m := NewManager(slog.New(slog.NewTextHandler(io.Discard, nil)), 5*time.Second)
+
+	first, err := m.Start(Spec{Root: root, PHP: php, Queue: "default"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := m.Start(Spec{Root: root, PHP: php, Queue: "emails"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer m.StopAll()
+
+	if got := m.List(root, "default"); len(got) != 1 || got[0].PID != first.PID {
+		t.Fatalf("Expected only the default worker, got %+v", got)
+	}
+	if got := m.List("", ""); len(got) != 2 {
+		t.Fatalf("Expected two workers, got %d", len(got))
+	}
+	if first.Spec.Connection != "redis" {
+		t.Errorf("Expected default connection redis, got %q", first.Spec.Connection)
+	}
+
+	if err := m.Stop(first.PID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := m.List(root, "default"); len(got) != 0 {
+		t.Errorf("Expected stopped worker to be forgotten, got %+v", got)
+	}
+	if err := m.Stop(first.PID); err == nil {
+		t.Error("Expected error stopping an unknown PID")
+	}
+}
+
+func TestManagerKillsAfterGracePeriod(t *testing.T) {
+	php := fakePHP(t, `trap '' TERM; touch ready; while :; do sleep 0.05; done`)
+	m := NewManager(slog.New(slog.NewTextHandler(io.Discard, nil)), 200*time.Millisecond)
+
+	root := t.TempDir()
+	w, err := m.Start(Spec{Root: root, PHP: php, Queue: "default"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Wait until the worker ignores SIGTERM
+	for i := 0; i < 100; i++ {
+		if _, err := os.Stat(filepath.Join(root, "ready")); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	start := time.Now()
+	if err := m.Stop(w.PID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("Expected Stop to wait for the grace period, took %s", elapsed)
+	}
+	if len(m.List("", "")) != 0 {
+		t.Error("Expected killed worker to be forgotten")
+	}
+}
+
+func TestManagerStartValidation(t *testing.T) {
+	m := NewManager(slog.New(slog.NewTextHandler(io.Discard, nil)), 0)
+	if _, err := m.Start(Spec{Queue: "default"}); err == nil {
+		t.Error("Expected error without root")
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package workers
+
+import "syscall"
+
+// newSysProcAttr places workers in their own process group
+func newSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setpgid: true}
+}
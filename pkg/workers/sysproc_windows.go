@@ -0,0 +1,10 @@
+//go:build windows
+
+package workers
+
+import "syscall"
+
+// newSysProcAttr uses default process attributes on Windows
+func newSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{}
+}